@@ -0,0 +1,77 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/kafka"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/guimartiins/eda-go/internal/balances/consumer"
+	"github.com/guimartiins/eda-go/internal/balances/database"
+	"github.com/guimartiins/eda-go/internal/balances/usecase/get_balance"
+	"github.com/guimartiins/eda-go/internal/balances/usecase/update_balance"
+	"github.com/guimartiins/eda-go/internal/balances/web"
+	"github.com/guimartiins/eda-go/internal/web/webserver"
+	"github.com/guimartiins/eda-go/pkg/kafka"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	// BALANCES_DB_DRIVER=sqlite3 runs the service on a local file instead of
+	// MySQL; the schema is in migrations/balances.
+	driver := getenv("BALANCES_DB_DRIVER", "mysql")
+	dsn := getenv("BALANCES_DB_DSN", fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local",
+		"root",      // username
+		"root",      // password
+		"mysql",     // host
+		"3306",      // port
+		"balances")) // database name
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		panic(fmt.Errorf("error connecting to the database: %v", err))
+	}
+	fmt.Println("Successfully connected to database")
+
+	balanceDb := database.NewBalanceDB(db)
+	balanceDb.SQLite = driver == "sqlite3"
+	updateBalanceUseCase := update_balance.NewUpdateBalanceUseCase(balanceDb)
+	getBalanceUseCase := get_balance.NewGetBalanceUseCase(balanceDb)
+
+	configMap := ckafka.ConfigMap{
+		"bootstrap.servers": "kafka:29092",
+		"group.id":          "balances",
+		"auto.offset.reset": "earliest",
+	}
+	balanceConsumer := consumer.NewBalanceUpdatedConsumer(updateBalanceUseCase)
+	msgChan := make(chan *ckafka.Message)
+	go kafka.NewConsumer(&configMap, []string{"balances"}).Consume(msgChan)
+	go func() {
+		for msg := range msgChan {
+			if err := balanceConsumer.Handle(msg.Value); err != nil {
+				fmt.Println("error handling balance update:", err)
+			}
+		}
+	}()
+
+	webserver := webserver.NewWebServer("3003")
+	balanceHandler := web.NewWebBalanceHandler(*getBalanceUseCase)
+	webserver.AddHandler(http.MethodGet, "/balances/{account_id}", balanceHandler.GetBalance)
+
+	fmt.Println("Starting web server")
+	webserver.Start()
+}
+
+func getenv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
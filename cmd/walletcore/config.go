@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+)
+
+// transferPolicyFromEnv reads the transfer rules of the deployment:
+// TRANSFER_MIN_AMOUNT and TRANSFER_MAX_AMOUNT bound the amount of a transfer,
+// and TRANSFER_CURRENCY_PAIRS lists the allowed cross-currency transfers as
+// comma-separated FROM:TO pairs, such as "USD:BRL,EUR:BRL".
+// TRANSFER_ALLOW_NEGATIVE_REVERSALS=true lets a reversal leave its recipient
+// with a negative balance.
+func transferPolicyFromEnv() (entity.TransferPolicy, error) {
+	var policy entity.TransferPolicy
+	var err error
+
+	if value := os.Getenv("TRANSFER_MIN_AMOUNT"); value != "" {
+		if policy.MinAmount, err = strconv.ParseFloat(value, 64); err != nil {
+			return policy, fmt.Errorf("invalid TRANSFER_MIN_AMOUNT: %v", err)
+		}
+	}
+	if value := os.Getenv("TRANSFER_MAX_AMOUNT"); value != "" {
+		if policy.MaxAmount, err = strconv.ParseFloat(value, 64); err != nil {
+			return policy, fmt.Errorf("invalid TRANSFER_MAX_AMOUNT: %v", err)
+		}
+	}
+	if value := os.Getenv("TRANSFER_CURRENCY_PAIRS"); value != "" {
+		for _, pair := range strings.Split(value, ",") {
+			from, to, ok := strings.Cut(strings.TrimSpace(pair), ":")
+			if !ok || from == "" || to == "" {
+				return policy, fmt.Errorf("invalid TRANSFER_CURRENCY_PAIRS: %q is not a FROM:TO pair", pair)
+			}
+			policy.AllowedCurrencyPairs = append(policy.AllowedCurrencyPairs, entity.CurrencyPair{From: from, To: to})
+		}
+	}
+
+	if value := os.Getenv("TRANSFER_ALLOW_NEGATIVE_REVERSALS"); value != "" {
+		if policy.AllowNegativeReversals, err = strconv.ParseBool(value); err != nil {
+			return policy, fmt.Errorf("invalid TRANSFER_ALLOW_NEGATIVE_REVERSALS: %v", err)
+		}
+	}
+
+	return policy, nil
+}
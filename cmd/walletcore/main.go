@@ -4,15 +4,43 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
 
 	ckafka "github.com/confluentinc/confluent-kafka-go/kafka"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
 	"github.com/guimartiins/eda-go/internal/event"
 	"github.com/guimartiins/eda-go/internal/event/handler"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/internal/usecase/authorize_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/capture_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/close_account"
 	create_account "github.com/guimartiins/eda-go/internal/usecase/create_account"
 	create_client "github.com/guimartiins/eda-go/internal/usecase/create_client"
+	"github.com/guimartiins/eda-go/internal/usecase/create_deposit"
 	create_transaction "github.com/guimartiins/eda-go/internal/usecase/create_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/create_withdrawal"
+	"github.com/guimartiins/eda-go/internal/usecase/delete_client"
+	"github.com/guimartiins/eda-go/internal/usecase/expire_transactions"
+	"github.com/guimartiins/eda-go/internal/usecase/freeze_account"
+	"github.com/guimartiins/eda-go/internal/usecase/get_account"
+	"github.com/guimartiins/eda-go/internal/usecase/get_client"
+	"github.com/guimartiins/eda-go/internal/usecase/get_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/list_account_transactions"
+	"github.com/guimartiins/eda-go/internal/usecase/list_client_accounts"
+	"github.com/guimartiins/eda-go/internal/usecase/list_clients"
+	"github.com/guimartiins/eda-go/internal/usecase/reverse_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/unfreeze_account"
+	"github.com/guimartiins/eda-go/internal/usecase/update_client"
+	"github.com/guimartiins/eda-go/internal/usecase/update_spending_limits"
+	"github.com/guimartiins/eda-go/internal/usecase/void_transaction"
 	"github.com/guimartiins/eda-go/internal/web"
 	"github.com/guimartiins/eda-go/internal/web/webserver"
 	"github.com/guimartiins/eda-go/pkg/events"
@@ -45,42 +73,264 @@ func main() {
 	}
 
 	kafkaProducer := kafka.NewKafkaProducer(&configMap)
+	defer kafkaProducer.Close(10 * time.Second)
 
-	eventDispatcher := events.NewEventDispatcher()
-	transactionCreatedEvent := event.NewTransactionCreatedEvent()
-	eventDispatcher.Register("TransactionCreated", handler.NewTransactionCreatedKafkaHandler(kafkaProducer))
-	balanceUpdatedEvent := event.NewBalanceUpdatedEvent()
-	eventDispatcher.Register("BalanceUpdated", handler.NewUpdateBalanceKafkaHandler(kafkaProducer))
+	ctx := context.Background()
+
+	entity.DefaultTransferPolicy, err = transferPolicyFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		if err := runReplay(ctx, db, kafkaProducer, os.Args[2:]); err != nil {
+			fmt.Println("replay failed:", err)
+			kafkaProducer.Close(10 * time.Second)
+			os.Exit(1)
+		}
+		return
+	}
+
+	eventDispatcher := events.NewAsyncEventDispatcher(events.AsyncConfig{
+		QueueSize: 1000,
+		Workers:   4,
+		Overflow:  events.Block,
+	})
+	// No Timeout: the projection and Kafka handlers ignore their context, so
+	// a timed-out call would keep running while Retry starts it again.
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+	eventDispatcher.Use(
+		events.Logging(logger),
+		events.Retry(3, 100*time.Millisecond),
+		events.Recovery(),
+	)
+	registerProjections(eventDispatcher, db)
+	registerKafkaHandlers(eventDispatcher, kafkaProducer)
 
 	clientDb := database.NewClientDB(db)
 	accountDb := database.NewAccountDB(db)
+	transactionDb := database.NewTransactionDB(db)
+	statementDb := database.NewStatementDB(db)
 
-	ctx := context.Background()
 	uow := uow.NewUow(ctx, db)
 
+	// MySQL locks the rows of the accounts that move money, and of the
+	// transactions changed, with FOR UPDATE.
+	newAccountDB := func(tx *sql.Tx) *database.AccountDB {
+		accountDb := database.NewAccountDB(tx)
+		accountDb.ForUpdate = true
+		return accountDb
+	}
 	uow.Register("AccountDB", func(tx *sql.Tx) interface{} {
-		return database.NewAccountDB(db)
+		return newAccountDB(tx)
+	},
+	)
+	uow.Register("AccountEventStore", func(tx *sql.Tx) interface{} {
+		return database.NewEventSourcedAccountDB(database.NewEventStoreDB(tx), newAccountDB(tx))
+	},
+	)
+	uow.Register("ClientDB", func(tx *sql.Tx) interface{} {
+		return database.NewClientDB(tx)
 	},
 	)
 	uow.Register("TransactionDB", func(tx *sql.Tx) interface{} {
-		return database.NewTransactionDB(db)
+		transactionDb := database.NewTransactionDB(tx)
+		transactionDb.ForUpdate = true
+		return transactionDb
+	},
+	)
+	uow.Register("IdempotencyKeyDB", func(tx *sql.Tx) interface{} {
+		return database.NewIdempotencyKeyDB(tx)
 	},
 	)
 
 	createClientUseCase := create_client.NewCreateClientUseCase(clientDb)
 	createAccountUseCase := create_account.NewCreateAccountUseCase(accountDb, clientDb)
-	createTransactionUseCase := create_transaction.NewCreateTransactionUseCase(uow, eventDispatcher, transactionCreatedEvent, balanceUpdatedEvent)
+	getClientUseCase := get_client.NewGetClientUseCase(clientDb)
+	listClientAccountsUseCase := list_client_accounts.NewListClientAccountsUseCase(clientDb, accountDb)
+	updateClientUseCase := update_client.NewUpdateClientUseCase(clientDb, eventDispatcher)
+	deleteClientUseCase := delete_client.NewDeleteClientUseCase(uow, eventDispatcher)
+	listClientsUseCase := list_clients.NewListClientsUseCase(clientDb)
+	getAccountUseCase := get_account.NewGetAccountUseCase(accountDb)
+	updateSpendingLimitsUseCase := update_spending_limits.NewUpdateSpendingLimitsUseCase(accountDb)
+	freezeAccountUseCase := freeze_account.NewFreezeAccountUseCase(accountDb, eventDispatcher)
+	if mode := os.Getenv("ACCOUNT_FREEZE_MODE"); mode != "" {
+		if !slices.Contains(entity.FreezeModes, mode) {
+			panic(fmt.Errorf("invalid ACCOUNT_FREEZE_MODE: %q", mode))
+		}
+		freezeAccountUseCase.DefaultMode = mode
+	}
+	unfreezeAccountUseCase := unfreeze_account.NewUnfreezeAccountUseCase(accountDb, eventDispatcher)
+	closeAccountUseCase := close_account.NewCloseAccountUseCase(accountDb, eventDispatcher)
+	getTransactionUseCase := get_transaction.NewGetTransactionUseCase(transactionDb)
+	createTransactionUseCase := create_transaction.NewCreateTransactionUseCase(uow, eventDispatcher)
+	createTransactionUseCase.EventSourced = os.Getenv("ACCOUNT_PERSISTENCE") == "eventstore"
+	if ttl := os.Getenv("IDEMPOTENCY_KEY_TTL"); ttl != "" {
+		createTransactionUseCase.IdempotencyKeyTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			panic(fmt.Errorf("invalid IDEMPOTENCY_KEY_TTL: %v", err))
+		}
+	}
+	listAccountTransactionsUseCase := list_account_transactions.NewListAccountTransactionsUseCase(statementDb)
+	settlementAccountID := os.Getenv("SETTLEMENT_ACCOUNT_ID")
+	createDepositUseCase := create_deposit.NewCreateDepositUseCase(uow, eventDispatcher, settlementAccountID)
+	createDepositUseCase.EventSourced = createTransactionUseCase.EventSourced
+	createWithdrawalUseCase := create_withdrawal.NewCreateWithdrawalUseCase(uow, eventDispatcher, settlementAccountID)
+	createWithdrawalUseCase.EventSourced = createTransactionUseCase.EventSourced
+	reverseTransactionUseCase := reverse_transaction.NewReverseTransactionUseCase(uow, eventDispatcher)
+	reverseTransactionUseCase.EventSourced = createTransactionUseCase.EventSourced
+	authorizeTransactionUseCase := authorize_transaction.NewAuthorizeTransactionUseCase(uow, eventDispatcher)
+	authorizeTransactionUseCase.EventSourced = createTransactionUseCase.EventSourced
+	if ttl := os.Getenv("HOLD_TTL"); ttl != "" {
+		authorizeTransactionUseCase.HoldTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			panic(fmt.Errorf("invalid HOLD_TTL: %v", err))
+		}
+	}
+	captureTransactionUseCase := capture_transaction.NewCaptureTransactionUseCase(uow, eventDispatcher)
+	captureTransactionUseCase.EventSourced = createTransactionUseCase.EventSourced
+	voidTransactionUseCase := void_transaction.NewVoidTransactionUseCase(uow, eventDispatcher)
+	voidTransactionUseCase.EventSourced = createTransactionUseCase.EventSourced
+	expireTransactionsUseCase := expire_transactions.NewExpireTransactionsUseCase(uow, transactionDb, eventDispatcher)
+	expireTransactionsUseCase.EventSourced = createTransactionUseCase.EventSourced
 
 	webserver := webserver.NewWebServer("8080")
 
-	clientHandler := web.NewWebClientHandler(*createClientUseCase)
-	accountHandler := web.NewWebAccountHandler(*createAccountUseCase)
-	transactionHandler := web.NewWebTransactionHandler(*createTransactionUseCase)
+	clientHandler := web.NewWebClientHandler(*createClientUseCase, *getClientUseCase, *listClientAccountsUseCase,
+		*updateClientUseCase, *deleteClientUseCase, *listClientsUseCase)
+	accountHandler := web.NewWebAccountHandler(*createAccountUseCase, *getAccountUseCase, *updateSpendingLimitsUseCase)
+	transactionHandler := web.NewWebTransactionHandler(*createTransactionUseCase, *getTransactionUseCase, *reverseTransactionUseCase)
+	statementHandler := web.NewWebStatementHandler(*listAccountTransactionsUseCase)
+	fundsHandler := web.NewWebFundsHandler(*createDepositUseCase, *createWithdrawalUseCase)
+	accountStatusHandler := web.NewWebAccountStatusHandler(*freezeAccountUseCase, *unfreezeAccountUseCase, *closeAccountUseCase)
+	authorizationHandler := web.NewWebAuthorizationHandler(*authorizeTransactionUseCase, *captureTransactionUseCase, *voidTransactionUseCase)
 
-	webserver.AddHandler("/clients", clientHandler.CreateClient)
-	webserver.AddHandler("/accounts", accountHandler.CreateAccount)
-	webserver.AddHandler("/transactions", transactionHandler.CreateTransaction)
+	clients := webserver.Group("/clients")
+	clients.AddHandler(http.MethodPost, "/", clientHandler.CreateClient)
+	clients.AddHandler(http.MethodGet, "/", clientHandler.ListClients)
+	clients.AddHandler(http.MethodGet, "/{id}", clientHandler.GetClient)
+	clients.AddHandler(http.MethodPut, "/{id}", clientHandler.UpdateClient)
+	clients.AddHandler(http.MethodDelete, "/{id}", clientHandler.DeleteClient)
+	clients.AddHandler(http.MethodGet, "/{id}/accounts", clientHandler.ListAccounts)
+
+	accounts := webserver.Group("/accounts")
+	accounts.AddHandler(http.MethodPost, "/", accountHandler.CreateAccount)
+	accounts.AddHandler(http.MethodGet, "/{id}", accountHandler.GetAccount)
+	accounts.AddHandler(http.MethodGet, "/{id}/transactions", statementHandler.ListAccountTransactions)
+	accounts.AddHandler(http.MethodPut, "/{id}/spending-limits", accountHandler.UpdateSpendingLimits)
+	accounts.AddHandler(http.MethodPost, "/{id}/freeze", accountStatusHandler.FreezeAccount)
+	accounts.AddHandler(http.MethodPost, "/{id}/unfreeze", accountStatusHandler.UnfreezeAccount)
+	accounts.AddHandler(http.MethodPost, "/{id}/close", accountStatusHandler.CloseAccount)
+	// Deposits and withdrawals are only served once a settlement account,
+	// balancing the money entering and leaving the wallet, is configured.
+	if settlementAccountID != "" {
+		accounts.AddHandler(http.MethodPost, "/{id}/deposits", fundsHandler.CreateDeposit)
+		accounts.AddHandler(http.MethodPost, "/{id}/withdrawals", fundsHandler.CreateWithdrawal)
+	}
+
+	transactions := webserver.Group("/transactions")
+	transactions.AddHandler(http.MethodPost, "/", transactionHandler.CreateTransaction)
+	transactions.AddHandler(http.MethodGet, "/{id}", transactionHandler.GetTransaction)
+	transactions.AddHandler(http.MethodPost, "/{id}/reversal", transactionHandler.ReverseTransaction)
+	transactions.AddHandler(http.MethodPost, "/authorizations", authorizationHandler.AuthorizeTransaction)
+	transactions.AddHandler(http.MethodPost, "/{id}/capture", authorizationHandler.CaptureTransaction)
+	transactions.AddHandler(http.MethodPost, "/{id}/void", authorizationHandler.VoidTransaction)
 
 	fmt.Println("Starting web server")
-	webserver.Start()
+	go webserver.Start()
+
+	signalCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go sweepIdempotencyKeys(signalCtx, database.NewIdempotencyKeyDB(db), time.Hour)
+	go expireHolds(signalCtx, expireTransactionsUseCase, time.Minute)
+	<-signalCtx.Done()
+
+	// The requests being served still raise events, so the server stops
+	// before the dispatcher drains.
+	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := webserver.Shutdown(shutdownCtx); err != nil {
+		fmt.Println("error shutting down the web server:", err)
+	}
+	if err := eventDispatcher.Shutdown(shutdownCtx); err != nil {
+		fmt.Println("error draining events:", err)
+	}
+}
+
+// registerProjections registers the handlers maintaining the local read
+// models. They also run on "walletcore replay -target=local".
+func registerProjections(dispatcher *events.EventDispatcher, db *sql.DB) {
+	// Projections run before the Kafka handlers so that a client notified
+	// through Kafka can already read its statement. That takes running the
+	// handlers of these events sequentially, in priority order.
+	statementHandler := handler.NewTransactionStatementHandler(database.NewStatementDB(db))
+	for _, name := range []string{
+		event.TransactionCreatedName,
+		event.DepositReceivedName,
+		event.WithdrawalCompletedName,
+		event.TransactionReversedName,
+		event.TransactionCapturedName,
+	} {
+		dispatcher.SetMode(name, events.Sequential)
+		dispatcher.Register(name, statementHandler, events.WithPriority(10))
+	}
+}
+
+func registerKafkaHandlers(dispatcher events.EventDispatcherInterface, kafkaProducer *kafka.Producer) {
+	transactionsHandler := handler.NewTransactionCreatedKafkaHandler(kafkaProducer)
+	dispatcher.Register(event.TransactionCreatedName, transactionsHandler)
+	dispatcher.Register(event.DepositReceivedName, transactionsHandler)
+	dispatcher.Register(event.WithdrawalCompletedName, transactionsHandler)
+	dispatcher.Register(event.TransactionReversedName, transactionsHandler)
+	for _, name := range []string{
+		event.TransactionAuthorizedName,
+		event.TransactionFailedName,
+		event.TransactionCapturedName,
+		event.TransactionVoidedName,
+		event.TransactionExpiredName,
+	} {
+		dispatcher.Register(name, transactionsHandler)
+	}
+	dispatcher.Register(event.BalanceUpdatedName, handler.NewUpdateBalanceKafkaHandler(kafkaProducer))
+	accountsHandler := handler.NewKafkaTopicHandler(kafkaProducer, "accounts")
+	dispatcher.Register(event.BalanceBelowZeroName, accountsHandler)
+	dispatcher.Register(event.AccountStatusChangedName, accountsHandler)
+	clientsHandler := handler.NewKafkaTopicHandler(kafkaProducer, "clients")
+	dispatcher.Register(event.ClientUpdatedName, clientsHandler)
+	dispatcher.Register(event.ClientDeletedName, clientsHandler)
+}
+
+// sweepIdempotencyKeys deletes expired idempotency keys every interval until
+// ctx is done. Expired keys are ignored anyway; this only bounds the table.
+func sweepIdempotencyKeys(ctx context.Context, keys gateway.IdempotencyKeyGateway, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := keys.DeleteExpired(now); err != nil {
+				fmt.Println("error deleting expired idempotency keys:", err)
+			}
+		}
+	}
+}
+
+// expireHolds releases the holds of expired authorizations every interval
+// until ctx is done.
+func expireHolds(ctx context.Context, uc *expire_transactions.ExpireTransactionsUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := uc.Execute(ctx, expire_transactions.ExpireTransactionsInputDTO{Now: now}); err != nil {
+				fmt.Println("error expiring holds:", err)
+			}
+		}
+	}
 }
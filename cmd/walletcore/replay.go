@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/event/handler"
+	"github.com/guimartiins/eda-go/internal/usecase/replay_transactions"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/kafka"
+)
+
+// runReplay implements "walletcore replay", which regenerates the events of
+// stored transactions for the local projections or for Kafka.
+func runReplay(ctx context.Context, db *sql.DB, kafkaProducer *kafka.Producer, args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	from := flags.String("from", "", "replay transactions created at or after this RFC 3339 time (default: all)")
+	to := flags.String("to", "", "replay transactions created at or before this RFC 3339 time (default: now)")
+	fromID := flags.String("from-id", "", "start at this transaction")
+	toID := flags.String("to-id", "", "stop after this transaction")
+	target := flags.String("target", "local", "where events go: local projections or kafka")
+	topic := flags.String("topic", "", "with -target=kafka, publish every event to this topic instead of its usual one")
+	dryRun := flags.Bool("dry-run", false, "count the events without dispatching them")
+	rate := flags.Float64("rate", 0, "maximum transactions replayed per second (default: no limit)")
+	flags.Parse(args)
+
+	input := replay_transactions.ReplayTransactionsInputDTO{
+		FromID:        *fromID,
+		ToID:          *toID,
+		DryRun:        *dryRun,
+		RatePerSecond: *rate,
+	}
+	var err error
+	if *from != "" {
+		if input.From, err = time.Parse(time.RFC3339, *from); err != nil {
+			return fmt.Errorf("invalid -from: %w", err)
+		}
+	}
+	if *to != "" {
+		if input.To, err = time.Parse(time.RFC3339, *to); err != nil {
+			return fmt.Errorf("invalid -to: %w", err)
+		}
+	}
+
+	dispatcher := events.NewEventDispatcher()
+	switch *target {
+	case "local":
+		registerProjections(dispatcher, db)
+	case "kafka":
+		if *topic != "" {
+			dispatcher.RegisterPattern("*", handler.NewKafkaTopicHandler(kafkaProducer, *topic))
+		} else {
+			registerKafkaHandlers(dispatcher, kafkaProducer)
+		}
+	default:
+		return fmt.Errorf("invalid -target %q", *target)
+	}
+
+	useCase := replay_transactions.NewReplayTransactionsUseCase(database.NewTransactionDB(db), database.NewAccountDB(db), dispatcher)
+	output, err := useCase.Execute(ctx, input)
+	if output != nil {
+		verb := "replayed"
+		if input.DryRun {
+			verb = "would replay"
+		}
+		fmt.Printf("%s %d transactions (%d events), last transaction: %q\n", verb, output.Transactions, output.Events, output.LastTransactionID)
+	}
+	return err
+}
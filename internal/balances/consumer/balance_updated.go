@@ -0,0 +1,43 @@
+package consumer
+
+import (
+	"encoding/json"
+
+	"github.com/guimartiins/eda-go/internal/balances/usecase/update_balance"
+	"github.com/guimartiins/eda-go/internal/event"
+)
+
+// BalanceUpdatedConsumer projects the BalanceUpdated messages of the balances
+// topic into the balances read model.
+type BalanceUpdatedConsumer struct {
+	UpdateBalanceUseCase *update_balance.UpdateBalanceUseCase
+}
+
+func NewBalanceUpdatedConsumer(updateBalanceUseCase *update_balance.UpdateBalanceUseCase) *BalanceUpdatedConsumer {
+	return &BalanceUpdatedConsumer{
+		UpdateBalanceUseCase: updateBalanceUseCase,
+	}
+}
+
+// Handle applies one message. Messages of other events are skipped.
+func (c *BalanceUpdatedConsumer) Handle(message []byte) error {
+	var balanceUpdated event.BalanceUpdated
+	if err := json.Unmarshal(message, &balanceUpdated); err != nil {
+		return err
+	}
+	if balanceUpdated.Name != event.BalanceUpdatedName {
+		return nil
+	}
+
+	_, err := c.UpdateBalanceUseCase.Execute(update_balance.UpdateBalanceInputDTO{
+		EventID:              balanceUpdated.ID,
+		OccurredAt:           balanceUpdated.OccurredAt,
+		AccountIDFrom:        balanceUpdated.Payload.AccountIDFrom,
+		AccountIDTo:          balanceUpdated.Payload.AccountIDTo,
+		BalanceAccountIDFrom: balanceUpdated.Payload.BalanceAccountIDFrom,
+		BalanceAccountIDTo:   balanceUpdated.Payload.BalanceAccountIDTo,
+		VersionAccountIDFrom: balanceUpdated.Payload.VersionAccountIDFrom,
+		VersionAccountIDTo:   balanceUpdated.Payload.VersionAccountIDTo,
+	})
+	return err
+}
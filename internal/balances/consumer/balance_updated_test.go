@@ -0,0 +1,68 @@
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/balances/entity"
+	"github.com/guimartiins/eda-go/internal/balances/usecase/update_balance"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type BalanceGatewayMock struct {
+	mock.Mock
+}
+
+func (m *BalanceGatewayMock) FindByAccountID(accountID string) (*entity.Balance, error) {
+	args := m.Called(accountID)
+	balance, _ := args.Get(0).(*entity.Balance)
+	return balance, args.Error(1)
+}
+
+func (m *BalanceGatewayMock) Save(balance *entity.Balance) error {
+	args := m.Called(balance)
+	return args.Error(0)
+}
+
+func TestBalanceUpdatedConsumer_Handle(t *testing.T) {
+	balanceUpdated := event.NewBalanceUpdatedEvent(context.Background(), "tx-1", event.BalanceUpdatedPayload{
+		AccountIDFrom:        "from",
+		AccountIDTo:          "to",
+		BalanceAccountIDFrom: 90,
+		BalanceAccountIDTo:   10,
+		VersionAccountIDFrom: 4,
+		VersionAccountIDTo:   2,
+	})
+	message, err := json.Marshal(balanceUpdated)
+	assert.Nil(t, err)
+
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", mock.Anything).Return(nil, sql.ErrNoRows)
+	m.On("Save", mock.MatchedBy(func(b *entity.Balance) bool {
+		return b.LastEventID == balanceUpdated.ID && (b.AccountID == "from" && b.Version == 4 || b.AccountID == "to" && b.Version == 2)
+	})).Return(nil)
+
+	c := NewBalanceUpdatedConsumer(update_balance.NewUpdateBalanceUseCase(m))
+	assert.Nil(t, c.Handle(message))
+	m.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestBalanceUpdatedConsumer_Handle_SkipsOtherEvents(t *testing.T) {
+	transactionCreated := event.NewTransactionCreatedEvent(context.Background(), event.TransactionCreatedPayload{ID: "tx-1"})
+	message, err := json.Marshal(transactionCreated)
+	assert.Nil(t, err)
+
+	m := &BalanceGatewayMock{}
+	c := NewBalanceUpdatedConsumer(update_balance.NewUpdateBalanceUseCase(m))
+	assert.Nil(t, c.Handle(message))
+	m.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestBalanceUpdatedConsumer_Handle_InvalidMessage(t *testing.T) {
+	c := NewBalanceUpdatedConsumer(update_balance.NewUpdateBalanceUseCase(&BalanceGatewayMock{}))
+	assert.NotNil(t, c.Handle([]byte("not json")))
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/guimartiins/eda-go/internal/balances/entity"
+)
+
+const insertBalance = "INSERT INTO balances (account_id, balance, version, updated_at, last_event_id) VALUES (?, ?, ?, ?, ?)"
+
+// The upserts of Save only replace a stored balance older than the saved one,
+// in the order of entity.Balance.Apply.
+const (
+	sqliteUpsertBalance = insertBalance + " ON CONFLICT(account_id) DO UPDATE SET" +
+		" balance = excluded.balance, version = excluded.version, updated_at = excluded.updated_at, last_event_id = excluded.last_event_id" +
+		" WHERE excluded.version > balances.version" +
+		" OR (excluded.version = 0 AND balances.version = 0 AND excluded.updated_at >= balances.updated_at)"
+
+	mysqlNewerBalance = "(VALUES(version) > version OR (VALUES(version) = 0 AND version = 0 AND VALUES(updated_at) >= updated_at))"
+	// MySQL assigns from left to right, so version and updated_at, which
+	// the condition reads, are assigned last.
+	mysqlUpsertBalance = insertBalance + " ON DUPLICATE KEY UPDATE" +
+		" balance = IF(" + mysqlNewerBalance + ", VALUES(balance), balance)," +
+		" last_event_id = IF(" + mysqlNewerBalance + ", VALUES(last_event_id), last_event_id)," +
+		" updated_at = IF(" + mysqlNewerBalance + ", VALUES(updated_at), updated_at)," +
+		" version = IF(" + mysqlNewerBalance + ", VALUES(version), version)"
+)
+
+type BalanceDB struct {
+	DB *sql.DB
+	// SQLite makes Save use the upsert syntax of SQLite instead of the one
+	// of MySQL.
+	SQLite bool
+}
+
+func NewBalanceDB(db *sql.DB) *BalanceDB {
+	return &BalanceDB{DB: db}
+}
+
+func (b *BalanceDB) FindByAccountID(accountID string) (*entity.Balance, error) {
+	balance := &entity.Balance{}
+
+	stmt, err := b.DB.Prepare("SELECT account_id, balance, version, updated_at, last_event_id FROM balances WHERE account_id = ?")
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRow(accountID)
+	if err := row.Scan(&balance.AccountID, &balance.Amount, &balance.Version, &balance.UpdatedAt, &balance.LastEventID); err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// Save inserts balance or, unless the stored balance of the account is
+// already as new, replaces it. It is a single statement, so that concurrent
+// consumers cannot overwrite a newer balance.
+func (b *BalanceDB) Save(balance *entity.Balance) error {
+	upsert := mysqlUpsertBalance
+	if b.SQLite {
+		upsert = sqliteUpsertBalance
+	}
+	_, err := b.DB.Exec(upsert, balance.AccountID, balance.Amount, balance.Version, balance.UpdatedAt, balance.LastEventID)
+	return err
+}
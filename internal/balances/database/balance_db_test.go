@@ -0,0 +1,83 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/balances/entity"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type BalanceDBTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	balanceDB *BalanceDB
+}
+
+func (s *BalanceDBTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	s.db = db
+	s.db.Exec("CREATE TABLE balances (account_id varchar(255) PRIMARY KEY, balance float, version bigint DEFAULT 0, updated_at datetime, last_event_id varchar(255))")
+	s.balanceDB = NewBalanceDB(db)
+	s.balanceDB.SQLite = true
+}
+
+func (s *BalanceDBTestSuite) TearDownSuite() {
+	defer s.db.Close()
+	s.db.Exec("DROP TABLE balances")
+}
+
+func (s *BalanceDBTestSuite) TestSaveAndFind() {
+	balance := entity.NewBalance("account-1")
+	balance.Apply("event-1", 0, time.Now(), 100)
+	s.Nil(s.balanceDB.Save(balance))
+
+	balance.Apply("event-2", 0, time.Now(), 50)
+	s.Nil(s.balanceDB.Save(balance))
+
+	found, err := s.balanceDB.FindByAccountID("account-1")
+	s.Nil(err)
+	s.Equal(50.0, found.Amount)
+	s.Equal("event-2", found.LastEventID)
+
+	var rows int
+	s.db.QueryRow("SELECT COUNT(*) FROM balances").Scan(&rows)
+	s.Equal(1, rows)
+}
+
+func (s *BalanceDBTestSuite) TestSaveKeepsNewerBalance() {
+	now := time.Now()
+	newer := entity.NewBalance("account-2")
+	newer.Apply("event-3", 3, now, 30)
+	s.Nil(s.balanceDB.Save(newer))
+
+	// Read before the newer balance was saved by another consumer.
+	older := entity.NewBalance("account-2")
+	older.Apply("event-2", 2, now.Add(time.Second), 20)
+	s.Nil(s.balanceDB.Save(older))
+
+	found, err := s.balanceDB.FindByAccountID("account-2")
+	s.Nil(err)
+	s.Equal(30.0, found.Amount)
+	s.Equal(int64(3), found.Version)
+	s.Equal("event-3", found.LastEventID)
+
+	newer.Apply("event-4", 4, now, 40)
+	s.Nil(s.balanceDB.Save(newer))
+	found, err = s.balanceDB.FindByAccountID("account-2")
+	s.Nil(err)
+	s.Equal(40.0, found.Amount)
+}
+
+func (s *BalanceDBTestSuite) TestFindWhenBalanceDoesNotExist() {
+	balance, err := s.balanceDB.FindByAccountID("invalid_id")
+	s.ErrorIs(err, sql.ErrNoRows)
+	s.Nil(balance)
+}
+
+func TestBalanceDBTestSuite(t *testing.T) {
+	suite.Run(t, new(BalanceDBTestSuite))
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// Balance is the last known balance of an account, as reported by the
+// BalanceUpdated events of walletcore.
+type Balance struct {
+	AccountID string
+	Amount    float64
+	// Version is the balance version of the account Amount was reported
+	// with, zero when it came from an event without one.
+	Version int64
+	// UpdatedAt is when the event that set Amount occurred, not when it was
+	// received.
+	UpdatedAt   time.Time
+	LastEventID string
+}
+
+func NewBalance(accountID string) *Balance {
+	return &Balance{AccountID: accountID}
+}
+
+// Apply sets the balance reported by an event. Events are ordered by the
+// balance version of the account, and the ones published before accounts had
+// one, whose version is zero, by when they occurred. Redelivered events and
+// events older than the current balance are ignored, and Apply returns false.
+func (b *Balance) Apply(eventID string, version int64, occurredAt time.Time, amount float64) bool {
+	if eventID == b.LastEventID || !b.isOlderThan(version, occurredAt) {
+		return false
+	}
+
+	b.Amount = amount
+	b.Version = version
+	b.UpdatedAt = occurredAt
+	b.LastEventID = eventID
+	return true
+}
+
+// isOlderThan reports whether b was set before a balance reported with
+// version at occurredAt.
+func (b *Balance) isOlderThan(version int64, occurredAt time.Time) bool {
+	if version > 0 {
+		return version > b.Version
+	}
+	return b.Version == 0 && !occurredAt.Before(b.UpdatedAt)
+}
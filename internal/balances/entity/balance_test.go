@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyBalance(t *testing.T) {
+	balance := NewBalance("account-1")
+	now := time.Now()
+
+	assert.True(t, balance.Apply("event-1", 0, now, 100))
+	assert.Equal(t, 100.0, balance.Amount)
+	assert.Equal(t, now, balance.UpdatedAt)
+
+	assert.True(t, balance.Apply("event-2", 0, now.Add(time.Second), 80))
+	assert.Equal(t, 80.0, balance.Amount)
+}
+
+func TestApplyBalanceIgnoresDuplicatesAndOlderEvents(t *testing.T) {
+	balance := NewBalance("account-1")
+	now := time.Now()
+	balance.Apply("event-2", 0, now, 80)
+
+	assert.False(t, balance.Apply("event-2", 0, now, 80))
+	assert.False(t, balance.Apply("event-1", 0, now.Add(-time.Second), 100))
+	assert.Equal(t, 80.0, balance.Amount)
+	assert.Equal(t, "event-2", balance.LastEventID)
+}
+
+func TestApplyBalanceOrdersByVersion(t *testing.T) {
+	balance := NewBalance("account-1")
+	now := time.Now()
+
+	assert.True(t, balance.Apply("event-1", 0, now, 100))
+	assert.True(t, balance.Apply("event-3", 3, now.Add(-time.Second), 70))
+	assert.False(t, balance.Apply("event-2", 2, now.Add(time.Second), 80))
+	assert.False(t, balance.Apply("event-4", 0, now.Add(time.Minute), 60))
+	assert.Equal(t, 70.0, balance.Amount)
+	assert.Equal(t, int64(3), balance.Version)
+}
@@ -0,0 +1,8 @@
+package gateway
+
+import "github.com/guimartiins/eda-go/internal/balances/entity"
+
+type BalanceGateway interface {
+	FindByAccountID(accountID string) (*entity.Balance, error)
+	Save(balance *entity.Balance) error
+}
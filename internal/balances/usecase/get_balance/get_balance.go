@@ -0,0 +1,40 @@
+package get_balance
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/balances/gateway"
+)
+
+type GetBalanceInputDTO struct {
+	AccountID string
+}
+
+type GetBalanceOutputDTO struct {
+	AccountID string    `json:"account_id"`
+	Balance   float64   `json:"balance"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GetBalanceUseCase struct {
+	BalanceGateway gateway.BalanceGateway
+}
+
+func NewGetBalanceUseCase(balanceGateway gateway.BalanceGateway) *GetBalanceUseCase {
+	return &GetBalanceUseCase{
+		BalanceGateway: balanceGateway,
+	}
+}
+
+func (uc *GetBalanceUseCase) Execute(input GetBalanceInputDTO) (*GetBalanceOutputDTO, error) {
+	balance, err := uc.BalanceGateway.FindByAccountID(input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetBalanceOutputDTO{
+		AccountID: balance.AccountID,
+		Balance:   balance.Amount,
+		UpdatedAt: balance.UpdatedAt,
+	}, nil
+}
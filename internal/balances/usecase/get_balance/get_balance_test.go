@@ -0,0 +1,39 @@
+package get_balance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/balances/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type BalanceGatewayMock struct {
+	mock.Mock
+}
+
+func (m *BalanceGatewayMock) FindByAccountID(accountID string) (*entity.Balance, error) {
+	args := m.Called(accountID)
+	return args.Get(0).(*entity.Balance), args.Error(1)
+}
+
+func (m *BalanceGatewayMock) Save(balance *entity.Balance) error {
+	args := m.Called(balance)
+	return args.Error(0)
+}
+
+func TestGetBalanceUseCase_Execute(t *testing.T) {
+	balance := entity.NewBalance("account-1")
+	balance.Apply("event-1", 0, time.Now(), 42)
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "account-1").Return(balance, nil)
+
+	uc := NewGetBalanceUseCase(m)
+	output, err := uc.Execute(GetBalanceInputDTO{AccountID: "account-1"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "account-1", output.AccountID)
+	assert.Equal(t, 42.0, output.Balance)
+	m.AssertExpectations(t)
+}
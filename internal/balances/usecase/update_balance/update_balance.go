@@ -0,0 +1,69 @@
+package update_balance
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/balances/entity"
+	"github.com/guimartiins/eda-go/internal/balances/gateway"
+)
+
+type UpdateBalanceInputDTO struct {
+	EventID              string
+	OccurredAt           time.Time
+	AccountIDFrom        string
+	AccountIDTo          string
+	BalanceAccountIDFrom float64
+	BalanceAccountIDTo   float64
+	VersionAccountIDFrom int64
+	VersionAccountIDTo   int64
+}
+
+type UpdateBalanceOutputDTO struct {
+	// Applied lists the accounts whose balance changed. Redelivered or
+	// outdated events leave it empty.
+	Applied []string
+}
+
+type UpdateBalanceUseCase struct {
+	BalanceGateway gateway.BalanceGateway
+}
+
+func NewUpdateBalanceUseCase(balanceGateway gateway.BalanceGateway) *UpdateBalanceUseCase {
+	return &UpdateBalanceUseCase{
+		BalanceGateway: balanceGateway,
+	}
+}
+
+func (uc *UpdateBalanceUseCase) Execute(input UpdateBalanceInputDTO) (*UpdateBalanceOutputDTO, error) {
+	output := &UpdateBalanceOutputDTO{}
+
+	for _, reported := range []struct {
+		accountID string
+		amount    float64
+		version   int64
+	}{
+		{input.AccountIDFrom, input.BalanceAccountIDFrom, input.VersionAccountIDFrom},
+		{input.AccountIDTo, input.BalanceAccountIDTo, input.VersionAccountIDTo},
+	} {
+		accountID := reported.accountID
+		balance, err := uc.BalanceGateway.FindByAccountID(accountID)
+		if errors.Is(err, sql.ErrNoRows) {
+			balance = entity.NewBalance(accountID)
+		} else if err != nil {
+			return nil, err
+		}
+
+		if !balance.Apply(input.EventID, reported.version, input.OccurredAt, reported.amount) {
+			continue
+		}
+
+		if err := uc.BalanceGateway.Save(balance); err != nil {
+			return nil, err
+		}
+		output.Applied = append(output.Applied, accountID)
+	}
+
+	return output, nil
+}
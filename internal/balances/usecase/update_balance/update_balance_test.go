@@ -0,0 +1,79 @@
+package update_balance
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/balances/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type BalanceGatewayMock struct {
+	mock.Mock
+}
+
+func (m *BalanceGatewayMock) FindByAccountID(accountID string) (*entity.Balance, error) {
+	args := m.Called(accountID)
+	balance, _ := args.Get(0).(*entity.Balance)
+	return balance, args.Error(1)
+}
+
+func (m *BalanceGatewayMock) Save(balance *entity.Balance) error {
+	args := m.Called(balance)
+	return args.Error(0)
+}
+
+func TestUpdateBalanceUseCase_Execute(t *testing.T) {
+	now := time.Now()
+	existing := entity.NewBalance("from")
+	existing.Apply("event-0", 0, now.Add(-time.Minute), 100)
+
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "from").Return(existing, nil)
+	m.On("FindByAccountID", "to").Return(nil, sql.ErrNoRows)
+	m.On("Save", mock.Anything).Return(nil)
+
+	uc := NewUpdateBalanceUseCase(m)
+	output, err := uc.Execute(UpdateBalanceInputDTO{
+		EventID:              "event-1",
+		OccurredAt:           now,
+		AccountIDFrom:        "from",
+		AccountIDTo:          "to",
+		BalanceAccountIDFrom: 90,
+		BalanceAccountIDTo:   10,
+	})
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"from", "to"}, output.Applied)
+	assert.Equal(t, 90.0, existing.Amount)
+	m.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestUpdateBalanceUseCase_Execute_OutdatedEvent(t *testing.T) {
+	now := time.Now()
+	from := entity.NewBalance("from")
+	from.Apply("event-2", 0, now, 50)
+	to := entity.NewBalance("to")
+	to.Apply("event-2", 0, now, 50)
+
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "from").Return(from, nil)
+	m.On("FindByAccountID", "to").Return(to, nil)
+
+	uc := NewUpdateBalanceUseCase(m)
+	output, err := uc.Execute(UpdateBalanceInputDTO{
+		EventID:              "event-1",
+		OccurredAt:           now.Add(-time.Second),
+		AccountIDFrom:        "from",
+		AccountIDTo:          "to",
+		BalanceAccountIDFrom: 90,
+		BalanceAccountIDTo:   10,
+	})
+
+	assert.Nil(t, err)
+	assert.Empty(t, output.Applied)
+	assert.Equal(t, 50.0, from.Amount)
+	m.AssertNotCalled(t, "Save", mock.Anything)
+}
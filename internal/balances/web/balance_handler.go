@@ -0,0 +1,31 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/balances/usecase/get_balance"
+	"github.com/guimartiins/eda-go/internal/web"
+)
+
+type WebBalanceHandler struct {
+	GetBalanceUseCase get_balance.GetBalanceUseCase
+}
+
+func NewWebBalanceHandler(getBalanceUseCase get_balance.GetBalanceUseCase) *WebBalanceHandler {
+	return &WebBalanceHandler{
+		GetBalanceUseCase: getBalanceUseCase,
+	}
+}
+
+func (h *WebBalanceHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	input := get_balance.GetBalanceInputDTO{AccountID: chi.URLParam(r, "account_id")}
+
+	output, err := h.GetBalanceUseCase.Execute(input)
+	if err != nil {
+		web.WriteProblem(w, r, err)
+		return
+	}
+
+	web.WriteJSON(w, r, http.StatusOK, output)
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/balances/entity"
+	"github.com/guimartiins/eda-go/internal/balances/usecase/get_balance"
+	"github.com/guimartiins/eda-go/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type BalanceGatewayMock struct {
+	mock.Mock
+}
+
+func (m *BalanceGatewayMock) FindByAccountID(accountID string) (*entity.Balance, error) {
+	args := m.Called(accountID)
+	balance, _ := args.Get(0).(*entity.Balance)
+	return balance, args.Error(1)
+}
+
+func (m *BalanceGatewayMock) Save(balance *entity.Balance) error {
+	return m.Called(balance).Error(0)
+}
+
+func serveGetBalance(m *BalanceGatewayMock, accountID string) *httptest.ResponseRecorder {
+	handler := NewWebBalanceHandler(*get_balance.NewGetBalanceUseCase(m))
+	router := chi.NewRouter()
+	router.Get("/balances/{account_id}", handler.GetBalance)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/balances/"+accountID, nil))
+	return recorder
+}
+
+func TestGetBalance(t *testing.T) {
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "a1").Return(&entity.Balance{AccountID: "a1", Amount: 10, UpdatedAt: time.Now()}, nil)
+
+	recorder := serveGetBalance(m, "a1")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var output get_balance.GetBalanceOutputDTO
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&output))
+	assert.Equal(t, 10.0, output.Balance)
+}
+
+func TestGetBalance_Problems(t *testing.T) {
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "unknown").Return(nil, sql.ErrNoRows)
+	m.On("FindByAccountID", "broken").Return(nil, errors.New("connection refused"))
+
+	recorder := serveGetBalance(m, "unknown")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, web.ProblemContentType, recorder.Header().Get("Content-Type"))
+	var problem web.Problem
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&problem))
+	assert.Equal(t, "not_found", problem.Code)
+
+	recorder = serveGetBalance(m, "broken")
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	var internal web.Problem
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&internal))
+	assert.Equal(t, "internal_error", internal.Code)
+	assert.Empty(t, internal.Detail)
+}
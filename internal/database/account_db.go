@@ -1,75 +1,225 @@
 package database
 
 import (
-	"database/sql"
+	"slices"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
 )
 
+const accountColumns = "a.id, a.balance, a.held_balance, a.balance_version, a.overdraft_limit, a.status, a.freeze_mode, a.status_reason, " +
+	"a.daily_amount_limit, a.daily_count_limit, a.monthly_amount_limit, a.monthly_count_limit, a.currency, a.created_at, " +
+	"c.id, c.name, c.email, c.credit_line, " +
+	"c.daily_amount_limit, c.daily_count_limit, c.monthly_amount_limit, c.monthly_count_limit, c.created_at, " +
+	"(" + creditLineUsedElsewhere + ")"
+
+// creditLineUsedElsewhere sums what the other accounts of the client of a
+// draw from its credit line, as entity.Account.CreditLineUsed does.
+const creditLineUsedElsewhere = "SELECT COALESCE(SUM(CASE WHEN o.held_balance - o.balance > o.overdraft_limit " +
+	"THEN o.held_balance - o.balance - o.overdraft_limit ELSE 0 END), 0) " +
+	"FROM accounts o WHERE o.client_id = a.client_id AND o.id <> a.id"
+
 type AccountDB struct {
-	db *sql.DB
+	db DBTX
+	// ForUpdate makes Lock take row locks with SELECT ... FOR UPDATE. It
+	// is left off for SQLite, which has no FOR UPDATE and serializes writers
+	// on the whole database instead.
+	ForUpdate bool
 }
 
-func NewAccountDB(db *sql.DB) *AccountDB {
+func NewAccountDB(db DBTX) *AccountDB {
 	return &AccountDB{db: db}
 }
 
 func (a *AccountDB) FindByID(id string) (*entity.Account, error) {
-	var account entity.Account
-	var client entity.Client
-	account.Client = &client
-
-	stmt, err := a.db.Prepare("SELECT a.id, a.client_id, a.balance, a.created_at, c.id, c.name, c.email, c.created_at FROM accounts a INNER JOIN clients c ON a.client_id = c.id WHERE a.id = ?")
+	stmt, err := a.db.Prepare("SELECT " + accountColumns + " FROM accounts a INNER JOIN clients c ON a.client_id = c.id WHERE a.id = ?")
 
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
 
-	row := stmt.QueryRow(id)
-	err = row.Scan(
-		&account.ID,
-		&account.Client.ID,
-		&account.Balance,
-		&account.CreatedAt,
-		&account.Client.ID,
-		&account.Client.Name,
-		&account.Client.Email,
-		&account.Client.CreatedAt,
-	)
+	return scanAccount(stmt.QueryRow(id))
+}
+
+// Lock locks the accounts and their clients, which serializes the money
+// movements touching the accounts and the transfers from all the accounts
+// drawing on the credit line of a client. The accounts are locked first and
+// then their clients, each in ascending ID order, so that transactions
+// locking overlapping accounts cannot deadlock. It fails with sql.ErrNoRows
+// when an account does not exist.
+func (a *AccountDB) Lock(ids ...string) error {
+	lockClause := ""
+	if a.ForUpdate {
+		lockClause = " FOR UPDATE"
+	}
 
+	var clientIDs []string
+	for _, id := range sortedUnique(ids) {
+		var clientID string
+		err := a.db.QueryRow("SELECT client_id FROM accounts WHERE id = ?"+lockClause, id).Scan(&clientID)
+		if err != nil {
+			return err
+		}
+		clientIDs = append(clientIDs, clientID)
+	}
+	for _, clientID := range sortedUnique(clientIDs) {
+		err := a.db.QueryRow("SELECT id FROM clients WHERE id = ?"+lockClause, clientID).Scan(&clientID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByClientID returns the accounts of a client, oldest first.
+func (a *AccountDB) FindByClientID(clientID string) ([]*entity.Account, error) {
+	rows, err := a.db.Query("SELECT "+accountColumns+" FROM accounts a INNER JOIN clients c ON a.client_id = c.id WHERE a.client_id = ? ORDER BY a.created_at, a.id", clientID)
 	if err != nil {
 		return nil, err
 	}
-	return &account, nil
+	defer rows.Close()
+
+	var accounts []*entity.Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
 }
 
 func (a *AccountDB) Save(account *entity.Account) error {
-	stmt, err := a.db.Prepare("INSERT INTO accounts (id, client_id, balance, created_at) VALUES (?, ?, ?, ?)")
+	stmt, err := a.db.Prepare("INSERT INTO accounts (id, client_id, balance, held_balance, balance_version, overdraft_limit, status, freeze_mode, status_reason, " +
+		"daily_amount_limit, daily_count_limit, monthly_amount_limit, monthly_count_limit, currency, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(account.ID, account.Client.ID, account.Balance, account.CreatedAt)
+	status := account.Status
+	if status == "" {
+		status = entity.AccountActive
+	}
+	limits := account.SpendingLimits
+	_, err = stmt.Exec(account.ID, account.Client.ID, account.Balance, account.Held, account.BalanceVersion, account.OverdraftLimit, status, account.FreezeMode, account.StatusReason,
+		limits.DailyAmount, limits.DailyCount, limits.MonthlyAmount, limits.MonthlyCount, account.Currency, account.CreatedAt)
 	if err != nil {
 		return err
 	}
 
+	account.MarkBalanceSaved(account.BalanceVersion)
 	return nil
 }
 
+// UpdateBalance saves both the balance and the held funds of account. The
+// stored balance version is bumped by the pending balance changes of account
+// and read back into it, so that it follows the order the updates commit in.
 func (a *AccountDB) UpdateBalance(account *entity.Account) error {
-	stmt, err := a.db.Prepare("UPDATE accounts SET balance = ? WHERE id = ?")
+	stmt, err := a.db.Prepare("UPDATE accounts SET balance = ?, held_balance = ?, balance_version = balance_version + ? WHERE id = ?")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(account.Balance, account.ID)
+	changes := account.PendingBalanceChanges()
+	_, err = stmt.Exec(account.Balance, account.Held, changes, account.ID)
 	if err != nil {
 		return err
 	}
+	if changes == 0 {
+		return nil
+	}
 
+	var version int64
+	if err := a.db.QueryRow("SELECT balance_version FROM accounts WHERE id = ?", account.ID).Scan(&version); err != nil {
+		return err
+	}
+	account.MarkBalanceSaved(version)
 	return nil
 }
+
+func (a *AccountDB) UpdateSpendingLimits(account *entity.Account) error {
+	limits := account.SpendingLimits
+	_, err := a.db.Exec("UPDATE accounts SET daily_amount_limit = ?, daily_count_limit = ?, monthly_amount_limit = ?, monthly_count_limit = ? WHERE id = ?",
+		limits.DailyAmount, limits.DailyCount, limits.MonthlyAmount, limits.MonthlyCount, account.ID)
+	return err
+}
+
+// UpdateStatus saves the status, freeze mode and status reason of account,
+// provided its stored status is still previousStatus. Otherwise another
+// change won the race and gateway.ErrVersionConflict is returned. Closing
+// also requires the stored balance and held funds to still be zero, failing
+// with entity.ErrAccountNotEmpty when money arrived since account was read.
+func (a *AccountDB) UpdateStatus(account *entity.Account, previousStatus string) error {
+	query := "UPDATE accounts SET status = ?, freeze_mode = ?, status_reason = ? WHERE id = ? AND status = ?"
+	if account.Status == entity.AccountClosed {
+		query += " AND ROUND(balance, 2) = 0 AND ROUND(held_balance, 2) = 0"
+	}
+	result, err := a.db.Exec(query, account.Status, account.FreezeMode, account.StatusReason, account.ID, previousStatus)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	if account.Status == entity.AccountClosed {
+		var status string
+		err = a.db.QueryRow("SELECT status FROM accounts WHERE id = ?", account.ID).Scan(&status)
+		if err != nil {
+			return err
+		}
+		if status == previousStatus {
+			return entity.ErrAccountNotEmpty
+		}
+	}
+	return gateway.ErrVersionConflict
+}
+
+func scanAccount(row rowScanner) (*entity.Account, error) {
+	account := &entity.Account{Client: &entity.Client{}}
+	err := row.Scan(
+		&account.ID,
+		&account.Balance,
+		&account.Held,
+		&account.BalanceVersion,
+		&account.OverdraftLimit,
+		&account.Status,
+		&account.FreezeMode,
+		&account.StatusReason,
+		&account.SpendingLimits.DailyAmount,
+		&account.SpendingLimits.DailyCount,
+		&account.SpendingLimits.MonthlyAmount,
+		&account.SpendingLimits.MonthlyCount,
+		&account.Currency,
+		&account.CreatedAt,
+		&account.Client.ID,
+		&account.Client.Name,
+		&account.Client.Email,
+		&account.Client.CreditLine,
+		&account.Client.SpendingLimits.DailyAmount,
+		&account.Client.SpendingLimits.DailyCount,
+		&account.Client.SpendingLimits.MonthlyAmount,
+		&account.Client.SpendingLimits.MonthlyCount,
+		&account.Client.CreatedAt,
+		&account.CreditLineUsedElsewhere,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func sortedUnique(values []string) []string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
@@ -2,9 +2,13 @@ package database
 
 import (
 	"database/sql"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/suite"
 )
@@ -20,8 +24,8 @@ func (s *AccountDBTestSuite) SetupSuite() {
 	db, err := sql.Open("sqlite3", ":memory:")
 	s.Nil(err)
 	s.db = db
-	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), created_at date)")
-	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, created_at date)")
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
 	s.accountDB = NewAccountDB(db)
 	s.client, _ = entity.NewClient("John", "j@j.com")
 	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)",
@@ -52,6 +56,219 @@ func (s *AccountDBTestSuite) TestFindByID() {
 	s.Equal(account.Client.Name, accountDB.Client.Name)
 	s.Equal(account.Client.Email, accountDB.Client.Email)
 	s.Equal(account.Balance, accountDB.Balance)
+	s.Equal(entity.DefaultCurrency, accountDB.Currency)
+}
+
+func (s *AccountDBTestSuite) TestSaveWithCurrency() {
+	account := entity.NewAccountInCurrency(s.client, "USD")
+	s.Nil(s.accountDB.Save(account))
+
+	accountDB, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal("USD", accountDB.Currency)
+}
+
+func (s *AccountDBTestSuite) TestUpdateBalanceWithHeldFunds() {
+	account := entity.NewAccount(s.client)
+	account.Credit(100)
+	s.Nil(s.accountDB.Save(account))
+	account.Hold("t1", 40)
+	s.Nil(s.accountDB.UpdateBalance(account))
+
+	accountDB, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(100.0, accountDB.Balance)
+	s.Equal(40.0, accountDB.Held)
+	s.Equal(60.0, accountDB.AvailableBalance())
+}
+
+func (s *AccountDBTestSuite) TestUpdateBalanceBumpsBalanceVersion() {
+	account := entity.NewAccount(s.client)
+	account.Credit(100)
+	s.Nil(s.accountDB.Save(account))
+	s.Equal(int64(1), account.BalanceVersion)
+
+	// Another update committed meanwhile.
+	_, err := s.db.Exec("UPDATE accounts SET balance_version = balance_version + 1 WHERE id = ?", account.ID)
+	s.Nil(err)
+	account.Debit(30)
+	s.Nil(s.accountDB.UpdateBalance(account))
+	s.Equal(int64(3), account.BalanceVersion)
+	s.Zero(account.PendingBalanceChanges())
+
+	account.Hold("t1", 10)
+	s.Nil(s.accountDB.UpdateBalance(account))
+	accountDB, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(int64(3), accountDB.BalanceVersion)
+}
+
+func (s *AccountDBTestSuite) TestUpdateStatus() {
+	account := entity.NewAccount(s.client)
+	s.Nil(s.accountDB.Save(account))
+
+	s.Nil(account.Freeze(entity.FreezeAll, entity.ReasonFraudSuspected))
+	s.Nil(s.accountDB.UpdateStatus(account, entity.AccountActive))
+	s.ErrorIs(s.accountDB.UpdateStatus(account, entity.AccountActive), gateway.ErrVersionConflict)
+
+	accountDB, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.AccountFrozen, accountDB.Status)
+	s.Equal(entity.FreezeAll, accountDB.FreezeMode)
+	s.Equal(entity.ReasonFraudSuspected, accountDB.StatusReason)
+}
+
+func (s *AccountDBTestSuite) TestUpdateStatus_CloseRequiresEmptyAccount() {
+	account := entity.NewAccount(s.client)
+	s.Nil(s.accountDB.Save(account))
+
+	// The account was read empty, then money arrived before it was closed.
+	funded := *account
+	funded.Credit(10)
+	s.Nil(s.accountDB.UpdateBalance(&funded))
+
+	s.Nil(account.Close(entity.ReasonCustomerRequest))
+	s.ErrorIs(s.accountDB.UpdateStatus(account, entity.AccountActive), entity.ErrAccountNotEmpty)
+
+	funded.Debit(10)
+	s.Nil(s.accountDB.UpdateBalance(&funded))
+	s.Nil(s.accountDB.UpdateStatus(account, entity.AccountActive))
+	s.ErrorIs(s.accountDB.UpdateStatus(account, entity.AccountActive), gateway.ErrVersionConflict)
+
+	accountDB, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.AccountClosed, accountDB.Status)
+}
+
+func (s *AccountDBTestSuite) TestUpdateStatus_CloseWithRoundingResidue() {
+	account := entity.NewAccount(s.client)
+	account.Credit(0.1)
+	account.Credit(0.2)
+	account.Debit(0.3)
+	s.Nil(s.accountDB.Save(account))
+
+	s.Nil(account.Close(entity.ReasonCustomerRequest))
+	s.Nil(s.accountDB.UpdateStatus(account, entity.AccountActive))
+}
+
+func (s *AccountDBTestSuite) TestLock() {
+	account := entity.NewAccount(s.client)
+	s.Nil(s.accountDB.Save(account))
+
+	s.Nil(s.accountDB.Lock(account.ID))
+	s.ErrorIs(s.accountDB.Lock("invalid_id"), sql.ErrNoRows)
+}
+
+func (s *AccountDBTestSuite) TestLockSeveralAccounts() {
+	first := entity.NewAccount(s.client)
+	second := entity.NewAccount(s.client)
+	s.Nil(s.accountDB.Save(first))
+	s.Nil(s.accountDB.Save(second))
+
+	s.Nil(s.accountDB.Lock(second.ID, first.ID, second.ID))
+	s.ErrorIs(s.accountDB.Lock(first.ID, "invalid_id"), sql.ErrNoRows)
+}
+
+// TestConcurrentMovements moves money between two accounts from many
+// transactions at once, as deposits do with the settlement account. SQLite
+// takes the write lock at BEGIN IMMEDIATE instead of with FOR UPDATE.
+func (s *AccountDBTestSuite) TestConcurrentMovements() {
+	db, err := sql.Open("sqlite3", filepath.Join(s.T().TempDir(), "wallet.db")+"?_txlock=immediate&_busy_timeout=10000")
+	s.Nil(err)
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.Nil(err)
+	_, err = db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.Nil(err)
+	client, _ := entity.NewClient("John", "j@j.com")
+	_, err = db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	s.Nil(err)
+	settlement := entity.NewAccount(client)
+	account := entity.NewAccount(client)
+	s.Nil(NewAccountDB(db).Save(settlement))
+	s.Nil(NewAccountDB(db).Save(account))
+
+	const movements = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, movements)
+	for i := 0; i < movements; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- moveMoney(db, settlement.ID, account.ID, 1)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		s.Nil(err)
+	}
+
+	accounts := NewAccountDB(db)
+	found, err := accounts.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(float64(movements), found.Balance)
+	s.Equal(int64(movements), found.BalanceVersion)
+	found, err = accounts.FindByID(settlement.ID)
+	s.Nil(err)
+	s.Equal(float64(-movements), found.Balance)
+}
+
+func moveMoney(db *sql.DB, fromID string, toID string, amount float64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	accounts := NewAccountDB(tx)
+	if err := accounts.Lock(toID, fromID); err != nil {
+		return err
+	}
+	from, err := accounts.FindByID(fromID)
+	if err != nil {
+		return err
+	}
+	to, err := accounts.FindByID(toID)
+	if err != nil {
+		return err
+	}
+	from.Debit(amount)
+	to.Credit(amount)
+	if err := accounts.UpdateBalance(from); err != nil {
+		return err
+	}
+	if err := accounts.UpdateBalance(to); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *AccountDBTestSuite) TestFindByID_CreditLineUsedElsewhere() {
+	client, _ := entity.NewClient("Joe", "joe@j.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, credit_line, created_at) VALUES (?, ?, ?, ?, ?)",
+		client.ID, client.Name, client.Email, 100, client.CreatedAt)
+	account := entity.NewAccount(client)
+	overdrawn := entity.NewAccount(client)
+	overdrawn.OverdraftLimit = 20
+	overdrawn.Debit(50)
+	held := entity.NewAccount(client)
+	held.Hold("t1", 10)
+	funded := entity.NewAccount(client)
+	funded.Credit(500)
+	for _, a := range []*entity.Account{account, overdrawn, held, funded} {
+		s.Nil(s.accountDB.Save(a))
+	}
+
+	found, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(40.0, found.CreditLineUsedElsewhere)
+	s.Equal(60.0, found.Limit())
+
+	found, err = s.accountDB.FindByID(overdrawn.ID)
+	s.Nil(err)
+	s.Equal(10.0, found.CreditLineUsedElsewhere)
+	s.Equal(30.0, found.CreditLineUsed())
 }
 
 func (s *AccountDBTestSuite) TestGetWhenAccountDoesNotExist() {
@@ -60,6 +277,28 @@ func (s *AccountDBTestSuite) TestGetWhenAccountDoesNotExist() {
 	s.Nil(account)
 }
 
+func (s *AccountDBTestSuite) TestFindByClientID() {
+	client, _ := entity.NewClient("Jane", "jane@j.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)",
+		client.ID, client.Name, client.Email, client.CreatedAt)
+	first := entity.NewAccount(client)
+	second := entity.NewAccount(client)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	s.Nil(s.accountDB.Save(second))
+	s.Nil(s.accountDB.Save(first))
+
+	accounts, err := s.accountDB.FindByClientID(client.ID)
+	s.Nil(err)
+	s.Len(accounts, 2)
+	s.Equal(first.ID, accounts[0].ID)
+	s.Equal(second.ID, accounts[1].ID)
+	s.Equal(client.Name, accounts[0].Client.Name)
+
+	accounts, err = s.accountDB.FindByClientID("invalid_id")
+	s.Nil(err)
+	s.Empty(accounts)
+}
+
 func TestAccountDBTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountDBTestSuite))
 }
@@ -2,39 +2,39 @@ package database
 
 import (
 	"database/sql"
+	"strings"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
 )
 
+const clientColumns = "id, name, email, credit_line, daily_amount_limit, daily_count_limit, monthly_amount_limit, monthly_count_limit, created_at, updated_at"
+
+// likeEscaper escapes the wildcards of a LIKE pattern, with ! as the escape
+// character so that MySQL and SQLite read it the same way.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
 type ClientDB struct {
-	DB *sql.DB
+	DB DBTX
 }
 
-func NewClientDB(db *sql.DB) *ClientDB {
+func NewClientDB(db DBTX) *ClientDB {
 	return &ClientDB{DB: db}
 }
 
 func (c *ClientDB) Get(id string) (*entity.Client, error) {
-	client := &entity.Client{}
-
-	stmt, err := c.DB.Prepare("SELECT id, name, email, created_at FROM clients WHERE id = ?")
+	stmt, err := c.DB.Prepare("SELECT " + clientColumns + " FROM clients WHERE id = ? AND deleted_at IS NULL")
 	if err != nil {
 		return nil, err
 	}
 
 	defer stmt.Close()
 
-	row := stmt.QueryRow(id)
-
-	if err := row.Scan(&client.ID, &client.Name, &client.Email, &client.CreatedAt); err != nil {
-		return nil, err
-	}
-
-	return client, nil
+	return scanClient(stmt.QueryRow(id))
 }
 
 func (c *ClientDB) Save(client *entity.Client) error {
-	stmt, err := c.DB.Prepare("INSERT INTO clients (id, name, email, created_at, updated_at) VALUES (?, ?, ?, ?, ?)")
+	stmt, err := c.DB.Prepare("INSERT INTO clients (" + clientColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		println("1 - Saving client to database:", err)
 
@@ -43,7 +43,9 @@ func (c *ClientDB) Save(client *entity.Client) error {
 
 	defer stmt.Close()
 
-	_, err = stmt.Exec(client.ID, client.Name, client.Email, client.CreatedAt, client.UpdatedAt)
+	limits := client.SpendingLimits
+	_, err = stmt.Exec(client.ID, client.Name, client.Email, client.CreditLine,
+		limits.DailyAmount, limits.DailyCount, limits.MonthlyAmount, limits.MonthlyCount, client.CreatedAt, client.UpdatedAt)
 	if err != nil {
 		println("2 - Saving client to database:", err)
 		return err
@@ -51,3 +53,86 @@ func (c *ClientDB) Save(client *entity.Client) error {
 
 	return nil
 }
+
+func (c *ClientDB) Update(client *entity.Client) error {
+	result, err := c.DB.Exec("UPDATE clients SET name = ?, email = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL",
+		client.Name, client.Email, client.UpdatedAt, client.ID)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+func (c *ClientDB) Delete(client *entity.Client) error {
+	result, err := c.DB.Exec("UPDATE clients SET deleted_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL",
+		client.DeletedAt, client.UpdatedAt, client.ID)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+func (c *ClientDB) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := []interface{}{}
+	if filter.Query != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Query) + "%"
+		conditions = append(conditions, "(name LIKE ? ESCAPE '!' OR email LIKE ? ESCAPE '!')")
+		args = append(args, pattern, pattern)
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+	query := "SELECT " + clientColumns + " FROM clients WHERE " + strings.Join(conditions, " AND ") + " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := c.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clients []*entity.Client
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, rows.Err()
+}
+
+// requireRow fails with sql.ErrNoRows when result changed no row.
+func requireRow(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func scanClient(row rowScanner) (*entity.Client, error) {
+	client := &entity.Client{}
+	// Clients saved before updated_at was written have none.
+	var updatedAt sql.NullTime
+	err := row.Scan(&client.ID, &client.Name, &client.Email, &client.CreditLine,
+		&client.SpendingLimits.DailyAmount, &client.SpendingLimits.DailyCount, &client.SpendingLimits.MonthlyAmount, &client.SpendingLimits.MonthlyCount,
+		&client.CreatedAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	client.UpdatedAt = updatedAt.Time
+	if client.UpdatedAt.IsZero() {
+		client.UpdatedAt = client.CreatedAt
+	}
+	return client, nil
+}
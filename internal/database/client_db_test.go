@@ -2,9 +2,12 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/suite"
 )
@@ -20,7 +23,7 @@ func (s *ClientDBTestSuite) SetupSuite() {
 	s.Nil(err)
 
 	s.db = db
-	db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), created_at date, updated_at date)")
+	db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date, updated_at date, deleted_at datetime)")
 	s.clientDB = NewClientDB(db)
 }
 
@@ -52,6 +55,65 @@ func (s *ClientDBTestSuite) TestGetWhenClientDoesNotExist() {
 	s.Nil(client)
 }
 
+func (s *ClientDBTestSuite) TestUpdate() {
+	client, _ := entity.NewClient("John", "j@j.com")
+	s.Nil(s.clientDB.Save(client))
+
+	s.Nil(client.Update("John Doe", "john@j.com"))
+	s.Nil(s.clientDB.Update(client))
+
+	clientDB, err := s.clientDB.Get(client.ID)
+	s.Nil(err)
+	s.Equal("John Doe", clientDB.Name)
+	s.Equal("john@j.com", clientDB.Email)
+	s.True(client.UpdatedAt.Equal(clientDB.UpdatedAt))
+}
+
+func (s *ClientDBTestSuite) TestDelete() {
+	client, _ := entity.NewClient("John", "j@j.com")
+	s.Nil(s.clientDB.Save(client))
+
+	s.Nil(client.Delete())
+	s.Nil(s.clientDB.Delete(client))
+	s.ErrorIs(s.clientDB.Delete(client), sql.ErrNoRows)
+	s.ErrorIs(s.clientDB.Update(client), sql.ErrNoRows)
+
+	_, err := s.clientDB.Get(client.ID)
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *ClientDBTestSuite) TestList() {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var clients []*entity.Client
+	for i, name := range []string{"Ana 100%", "Bruno 100%", "Carla 100%"} {
+		client, _ := entity.NewClient(name, fmt.Sprintf("client%d@list.com", i))
+		client.CreatedAt = createdAt.Add(time.Duration(i) * time.Hour)
+		s.Nil(s.clientDB.Save(client))
+		clients = append(clients, client)
+	}
+	deleted, _ := entity.NewClient("Daniel 100%", "daniel@list.com")
+	s.Nil(s.clientDB.Save(deleted))
+	s.Nil(deleted.Delete())
+	s.Nil(s.clientDB.Delete(deleted))
+	other, _ := entity.NewClient("Ana 1000", "ana@other.com")
+	s.Nil(s.clientDB.Save(other))
+
+	page, err := s.clientDB.List(gateway.ClientFilter{Query: "100%", Limit: 2})
+	s.Nil(err)
+	s.Len(page, 2)
+	s.Equal(clients[2].ID, page[0].ID)
+	s.Equal(clients[1].ID, page[1].ID)
+
+	page, err = s.clientDB.List(gateway.ClientFilter{Query: "100%", After: page[1], Limit: 2})
+	s.Nil(err)
+	s.Len(page, 1)
+	s.Equal(clients[0].ID, page[0].ID)
+
+	page, err = s.clientDB.List(gateway.ClientFilter{Query: "@LIST.com"})
+	s.Nil(err)
+	s.Len(page, 3)
+}
+
 func TestClientDBTestSuite(t *testing.T) {
 	suite.Run(t, new(ClientDBTestSuite))
 }
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+const (
+	accountAggregateType = "Account"
+	DefaultSnapshotEvery = 50
+)
+
+// EventSourcedAccountDB persists accounts as their stream of events. The
+// accounts table is still written as a projection, which is also where the
+// client of the account and accounts created before the event store come
+// from.
+type EventSourcedAccountDB struct {
+	Store         gateway.EventStoreGateway
+	Accounts      gateway.AccountGateway
+	SnapshotEvery int
+}
+
+func NewEventSourcedAccountDB(store gateway.EventStoreGateway, accounts gateway.AccountGateway) *EventSourcedAccountDB {
+	return &EventSourcedAccountDB{
+		Store:         store,
+		Accounts:      accounts,
+		SnapshotEvery: DefaultSnapshotEvery,
+	}
+}
+
+func (a *EventSourcedAccountDB) FindByID(id string) (*entity.Account, error) {
+	projected, err := a.Accounts.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot *entity.AccountSnapshot
+	stored, err := a.Store.LoadSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	afterVersion := 0
+	if stored != nil {
+		snapshot = &entity.AccountSnapshot{}
+		if err := json.Unmarshal(stored.State, snapshot); err != nil {
+			return nil, err
+		}
+		afterVersion = stored.Version
+	}
+
+	events, err := a.Store.Load(id, afterVersion)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil && len(events) == 0 {
+		return entity.ImportAccount(projected), nil
+	}
+
+	history := make([]entity.AccountEvent, 0, len(events))
+	for _, event := range events {
+		decoded, err := decodeAccountEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, decoded)
+	}
+
+	account := entity.RebuildAccount(snapshot, history)
+	account.Client = projected.Client
+	account.OverdraftLimit = projected.OverdraftLimit
+	account.CreditLineUsedElsewhere = projected.CreditLineUsedElsewhere
+	account.BalanceVersion = projected.BalanceVersion
+	account.SpendingLimits = projected.SpendingLimits
+	account.Status = projected.Status
+	account.FreezeMode = projected.FreezeMode
+	account.StatusReason = projected.StatusReason
+	return account, nil
+}
+
+// Lock locks the rows of the accounts in the projection.
+func (a *EventSourcedAccountDB) Lock(ids ...string) error {
+	return a.Accounts.Lock(ids...)
+}
+
+// FindByClientID reads the accounts of a client from the projection.
+func (a *EventSourcedAccountDB) FindByClientID(clientID string) ([]*entity.Account, error) {
+	return a.Accounts.FindByClientID(clientID)
+}
+
+func (a *EventSourcedAccountDB) Save(account *entity.Account) error {
+	if err := a.append(account); err != nil {
+		return err
+	}
+	return a.Accounts.Save(account)
+}
+
+func (a *EventSourcedAccountDB) UpdateBalance(account *entity.Account) error {
+	if err := a.append(account); err != nil {
+		return err
+	}
+	return a.Accounts.UpdateBalance(account)
+}
+
+// UpdateSpendingLimits saves the spending limits of account to the
+// projection; they are settings, not part of the history of the account.
+func (a *EventSourcedAccountDB) UpdateSpendingLimits(account *entity.Account) error {
+	return a.Accounts.UpdateSpendingLimits(account)
+}
+
+// UpdateStatus saves the status of account to the projection.
+func (a *EventSourcedAccountDB) UpdateStatus(account *entity.Account, previousStatus string) error {
+	return a.Accounts.UpdateStatus(account, previousStatus)
+}
+
+func (a *EventSourcedAccountDB) append(account *entity.Account) error {
+	changes := account.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	events := make([]gateway.StoredEvent, 0, len(changes))
+	for _, change := range changes {
+		payload, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		events = append(events, gateway.StoredEvent{
+			ID:            uuid.New().String(),
+			AggregateID:   account.ID,
+			AggregateType: accountAggregateType,
+			Name:          change.GetName(),
+			Payload:       payload,
+			OccurredAt:    change.GetDateTime(),
+		})
+	}
+
+	persistedVersion := account.PersistedVersion()
+	if err := a.Store.Append(account.ID, persistedVersion, events); err != nil {
+		return err
+	}
+	account.ClearChanges()
+
+	if a.SnapshotEvery > 0 && account.Version/a.SnapshotEvery > persistedVersion/a.SnapshotEvery {
+		state, err := json.Marshal(account.Snapshot())
+		if err != nil {
+			return err
+		}
+		return a.Store.SaveSnapshot(gateway.Snapshot{
+			AggregateID:   account.ID,
+			AggregateType: accountAggregateType,
+			Version:       account.Version,
+			State:         state,
+			CreatedAt:     time.Now(),
+		})
+	}
+
+	return nil
+}
+
+func decodeAccountEvent(event gateway.StoredEvent) (entity.AccountEvent, error) {
+	switch event.Name {
+	case entity.AccountOpened{}.GetName():
+		var e entity.AccountOpened
+		err := json.Unmarshal(event.Payload, &e)
+		return e, err
+	case entity.AccountCredited{}.GetName():
+		var e entity.AccountCredited
+		err := json.Unmarshal(event.Payload, &e)
+		return e, err
+	case entity.AccountDebited{}.GetName():
+		var e entity.AccountDebited
+		err := json.Unmarshal(event.Payload, &e)
+		return e, err
+	case entity.AccountFundsHeld{}.GetName():
+		var e entity.AccountFundsHeld
+		err := json.Unmarshal(event.Payload, &e)
+		return e, err
+	case entity.AccountFundsReleased{}.GetName():
+		var e entity.AccountFundsReleased
+		err := json.Unmarshal(event.Payload, &e)
+		return e, err
+	}
+
+	return nil, fmt.Errorf("unknown account event %q", event.Name)
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventSourcedAccountDBTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	accountDB *EventSourcedAccountDB
+	client    *entity.Client
+}
+
+func (s *EventSourcedAccountDBTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE events (id varchar(255), aggregate_id varchar(255), aggregate_type varchar(255), version int, name varchar(255), payload text, occurred_at date, UNIQUE (aggregate_id, version))")
+	s.db.Exec("CREATE TABLE snapshots (aggregate_id varchar(255), aggregate_type varchar(255), version int, state text, created_at date)")
+	s.accountDB = NewEventSourcedAccountDB(NewEventStoreDB(db), NewAccountDB(db))
+	s.accountDB.SnapshotEvery = 3
+	s.client, _ = entity.NewClient("John", "j@j.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)",
+		s.client.ID, s.client.Name, s.client.Email, s.client.CreatedAt)
+}
+
+func (s *EventSourcedAccountDBTestSuite) TearDownSuite() {
+	defer s.db.Close()
+	s.db.Exec("DROP TABLE snapshots")
+	s.db.Exec("DROP TABLE events")
+	s.db.Exec("DROP TABLE accounts")
+	s.db.Exec("DROP TABLE clients")
+}
+
+func (s *EventSourcedAccountDBTestSuite) TestSaveAndFindByID() {
+	account := entity.NewAccount(s.client)
+	account.Credit(100)
+	s.Nil(s.accountDB.Save(account))
+	s.Empty(account.Changes())
+
+	account.Debit(40)
+	s.Nil(s.accountDB.UpdateBalance(account))
+
+	found, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(60.0, found.Balance)
+	s.Equal(3, found.Version)
+	s.Equal(s.client.Name, found.Client.Name)
+
+	var projected float64
+	s.db.QueryRow("SELECT balance FROM accounts WHERE id = ?", account.ID).Scan(&projected)
+	s.Equal(60.0, projected)
+
+	var snapshots int
+	s.db.QueryRow("SELECT COUNT(*) FROM snapshots WHERE aggregate_id = ?", account.ID).Scan(&snapshots)
+	s.Equal(1, snapshots)
+}
+
+func (s *EventSourcedAccountDBTestSuite) TestUpdateBalanceWithStaleAccount() {
+	account := entity.NewAccount(s.client)
+	s.Nil(s.accountDB.Save(account))
+
+	first, _ := s.accountDB.FindByID(account.ID)
+	second, _ := s.accountDB.FindByID(account.ID)
+	first.Credit(10)
+	second.Credit(20)
+
+	s.Nil(s.accountDB.UpdateBalance(first))
+	s.Error(s.accountDB.UpdateBalance(second))
+}
+
+func (s *EventSourcedAccountDBTestSuite) TestFindByIDWithoutHistory() {
+	account := entity.NewAccount(s.client)
+	account.Credit(250)
+	s.Nil(NewAccountDB(s.db).Save(account))
+
+	found, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(250.0, found.Balance)
+
+	found.Debit(50)
+	s.Nil(s.accountDB.UpdateBalance(found))
+
+	found, err = s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(200.0, found.Balance)
+	s.Equal(2, found.Version)
+}
+
+func TestEventSourcedAccountDBTestSuite(t *testing.T) {
+	suite.Run(t, new(EventSourcedAccountDBTestSuite))
+}
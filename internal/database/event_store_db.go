@@ -0,0 +1,93 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+type EventStoreDB struct {
+	DB DBTX
+}
+
+func NewEventStoreDB(db DBTX) *EventStoreDB {
+	return &EventStoreDB{DB: db}
+}
+
+func (s *EventStoreDB) Append(aggregateID string, expectedVersion int, events []gateway.StoredEvent) error {
+	var version int
+	err := s.DB.QueryRow("SELECT COALESCE(MAX(version), 0) FROM events WHERE aggregate_id = ?", aggregateID).Scan(&version)
+	if err != nil {
+		return err
+	}
+	if version != expectedVersion {
+		return fmt.Errorf("%w: %s is at version %d, expected %d", gateway.ErrVersionConflict, aggregateID, version, expectedVersion)
+	}
+
+	stmt, err := s.DB.Prepare("INSERT INTO events (id, aggregate_id, aggregate_type, version, name, payload, occurred_at) VALUES (?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i, event := range events {
+		// The unique (aggregate_id, version) key rejects a concurrent append
+		// that read the same version.
+		_, err = stmt.Exec(event.ID, aggregateID, event.AggregateType, expectedVersion+i+1, event.Name, event.Payload, event.OccurredAt)
+		if isDuplicateKey(err) {
+			return fmt.Errorf("%w: %s was appended to concurrently at version %d", gateway.ErrVersionConflict, aggregateID, expectedVersion)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *EventStoreDB) Load(aggregateID string, afterVersion int) ([]gateway.StoredEvent, error) {
+	rows, err := s.DB.Query("SELECT id, aggregate_id, aggregate_type, version, name, payload, occurred_at FROM events WHERE aggregate_id = ? AND version > ? ORDER BY version", aggregateID, afterVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []gateway.StoredEvent
+	for rows.Next() {
+		var event gateway.StoredEvent
+		err = rows.Scan(&event.ID, &event.AggregateID, &event.AggregateType, &event.Version, &event.Name, &event.Payload, &event.OccurredAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (s *EventStoreDB) SaveSnapshot(snapshot gateway.Snapshot) error {
+	_, err := s.DB.Exec("DELETE FROM snapshots WHERE aggregate_id = ?", snapshot.AggregateID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec("INSERT INTO snapshots (aggregate_id, aggregate_type, version, state, created_at) VALUES (?, ?, ?, ?, ?)",
+		snapshot.AggregateID, snapshot.AggregateType, snapshot.Version, snapshot.State, snapshot.CreatedAt)
+	return err
+}
+
+func (s *EventStoreDB) LoadSnapshot(aggregateID string) (*gateway.Snapshot, error) {
+	snapshot := &gateway.Snapshot{}
+	row := s.DB.QueryRow("SELECT aggregate_id, aggregate_type, version, state, created_at FROM snapshots WHERE aggregate_id = ?", aggregateID)
+	err := row.Scan(&snapshot.AggregateID, &snapshot.AggregateType, &snapshot.Version, &snapshot.State, &snapshot.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventStoreDBTestSuite struct {
+	suite.Suite
+	db           *sql.DB
+	eventStoreDB *EventStoreDB
+}
+
+func (s *EventStoreDBTestSuite) SetupSuite() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	s.db = db
+	s.db.Exec("CREATE TABLE events (id varchar(255), aggregate_id varchar(255), aggregate_type varchar(255), version int, name varchar(255), payload text, occurred_at date, UNIQUE (aggregate_id, version))")
+	s.db.Exec("CREATE TABLE snapshots (aggregate_id varchar(255), aggregate_type varchar(255), version int, state text, created_at date)")
+	s.eventStoreDB = NewEventStoreDB(db)
+}
+
+func (s *EventStoreDBTestSuite) TearDownSuite() {
+	defer s.db.Close()
+	s.db.Exec("DROP TABLE events")
+	s.db.Exec("DROP TABLE snapshots")
+}
+
+func newStoredEvent(name string) gateway.StoredEvent {
+	return gateway.StoredEvent{
+		ID:            uuid.New().String(),
+		AggregateType: "Account",
+		Name:          name,
+		Payload:       []byte(`{}`),
+		OccurredAt:    time.Now(),
+	}
+}
+
+func (s *EventStoreDBTestSuite) TestAppendAndLoad() {
+	aggregateID := uuid.New().String()
+	err := s.eventStoreDB.Append(aggregateID, 0, []gateway.StoredEvent{newStoredEvent("AccountOpened"), newStoredEvent("AccountCredited")})
+	s.Nil(err)
+	err = s.eventStoreDB.Append(aggregateID, 2, []gateway.StoredEvent{newStoredEvent("AccountDebited")})
+	s.Nil(err)
+
+	events, err := s.eventStoreDB.Load(aggregateID, 0)
+	s.Nil(err)
+	s.Len(events, 3)
+	s.Equal("AccountOpened", events[0].Name)
+	s.Equal(1, events[0].Version)
+	s.Equal("AccountDebited", events[2].Name)
+	s.Equal(3, events[2].Version)
+
+	events, err = s.eventStoreDB.Load(aggregateID, 2)
+	s.Nil(err)
+	s.Len(events, 1)
+}
+
+func (s *EventStoreDBTestSuite) TestAppendWithStaleVersion() {
+	aggregateID := uuid.New().String()
+	s.Nil(s.eventStoreDB.Append(aggregateID, 0, []gateway.StoredEvent{newStoredEvent("AccountOpened")}))
+
+	err := s.eventStoreDB.Append(aggregateID, 0, []gateway.StoredEvent{newStoredEvent("AccountCredited")})
+	s.ErrorIs(err, gateway.ErrVersionConflict)
+}
+
+// staleVersionDB reads every aggregate at version 0, as an append does when
+// a concurrent one commits right after it read the version.
+type staleVersionDB struct {
+	*sql.DB
+}
+
+func (d staleVersionDB) QueryRow(query string, args ...any) *sql.Row {
+	return d.DB.QueryRow("SELECT 0")
+}
+
+func (s *EventStoreDBTestSuite) TestAppendConcurrently() {
+	aggregateID := uuid.New().String()
+	s.Nil(s.eventStoreDB.Append(aggregateID, 0, []gateway.StoredEvent{newStoredEvent("AccountOpened")}))
+
+	racing := NewEventStoreDB(staleVersionDB{s.db})
+	err := racing.Append(aggregateID, 0, []gateway.StoredEvent{newStoredEvent("AccountCredited")})
+	s.ErrorIs(err, gateway.ErrVersionConflict)
+}
+
+func (s *EventStoreDBTestSuite) TestSnapshot() {
+	aggregateID := uuid.New().String()
+	snapshot, err := s.eventStoreDB.LoadSnapshot(aggregateID)
+	s.Nil(err)
+	s.Nil(snapshot)
+
+	s.Nil(s.eventStoreDB.SaveSnapshot(gateway.Snapshot{AggregateID: aggregateID, AggregateType: "Account", Version: 50, State: []byte(`{"v":50}`), CreatedAt: time.Now()}))
+	s.Nil(s.eventStoreDB.SaveSnapshot(gateway.Snapshot{AggregateID: aggregateID, AggregateType: "Account", Version: 100, State: []byte(`{"v":100}`), CreatedAt: time.Now()}))
+
+	snapshot, err = s.eventStoreDB.LoadSnapshot(aggregateID)
+	s.Nil(err)
+	s.Equal(100, snapshot.Version)
+	s.Equal(`{"v":100}`, string(snapshot.State))
+}
+
+func TestEventStoreDBTestSuite(t *testing.T) {
+	suite.Run(t, new(EventStoreDBTestSuite))
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+type IdempotencyKeyDB struct {
+	DB DBTX
+}
+
+func NewIdempotencyKeyDB(db DBTX) *IdempotencyKeyDB {
+	return &IdempotencyKeyDB{DB: db}
+}
+
+func (i *IdempotencyKeyDB) Find(key string) (*entity.IdempotencyKey, error) {
+	idempotencyKey := &entity.IdempotencyKey{}
+	err := i.DB.QueryRow("SELECT idempotency_key, request_hash, response, created_at, expires_at FROM idempotency_keys WHERE idempotency_key = ?", key).
+		Scan(&idempotencyKey.Key, &idempotencyKey.RequestHash, &idempotencyKey.Response, &idempotencyKey.CreatedAt, &idempotencyKey.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return idempotencyKey, nil
+}
+
+func (i *IdempotencyKeyDB) Save(key *entity.IdempotencyKey) error {
+	stmt, err := i.DB.Prepare("INSERT INTO idempotency_keys (idempotency_key, request_hash, response, created_at, expires_at) VALUES (?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(key.Key, key.RequestHash, key.Response, key.CreatedAt, key.ExpiresAt)
+	if isDuplicateKey(err) {
+		return gateway.ErrIdempotencyKeyExists
+	}
+	return err
+}
+
+func (i *IdempotencyKeyDB) Delete(key string) error {
+	_, err := i.DB.Exec("DELETE FROM idempotency_keys WHERE idempotency_key = ?", key)
+	return err
+}
+
+func (i *IdempotencyKeyDB) DeleteExpired(now time.Time) (int64, error) {
+	result, err := i.DB.Exec("DELETE FROM idempotency_keys WHERE expires_at <= ?", now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyKeyDBTestSuite struct {
+	suite.Suite
+	db               *sql.DB
+	idempotencyKeyDB *IdempotencyKeyDB
+}
+
+func (s *IdempotencyKeyDBTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	s.db = db
+	_, err = s.db.Exec("CREATE TABLE idempotency_keys (idempotency_key varchar(255) PRIMARY KEY, request_hash char(64), response blob, created_at datetime, expires_at datetime)")
+	s.Nil(err)
+	s.idempotencyKeyDB = NewIdempotencyKeyDB(db)
+}
+
+func (s *IdempotencyKeyDBTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *IdempotencyKeyDBTestSuite) TestSaveAndFind() {
+	key := entity.NewIdempotencyKey("key", "hash", []byte(`{"id":"1"}`), time.Hour)
+	s.Nil(s.idempotencyKeyDB.Save(key))
+
+	found, err := s.idempotencyKeyDB.Find("key")
+	s.Nil(err)
+	s.Equal("hash", found.RequestHash)
+	s.Equal(`{"id":"1"}`, string(found.Response))
+	s.WithinDuration(key.ExpiresAt, found.ExpiresAt, time.Millisecond)
+
+	s.ErrorIs(s.idempotencyKeyDB.Save(key), gateway.ErrIdempotencyKeyExists)
+
+	_, err = s.idempotencyKeyDB.Find("unknown")
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *IdempotencyKeyDBTestSuite) TestDelete() {
+	s.Nil(s.idempotencyKeyDB.Save(entity.NewIdempotencyKey("key", "hash", []byte(`{}`), time.Hour)))
+
+	s.Nil(s.idempotencyKeyDB.Delete("key"))
+
+	_, err := s.idempotencyKeyDB.Find("key")
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *IdempotencyKeyDBTestSuite) TestDeleteExpired() {
+	s.Nil(s.idempotencyKeyDB.Save(entity.NewIdempotencyKey("expired", "hash", []byte(`{}`), -time.Minute)))
+	s.Nil(s.idempotencyKeyDB.Save(entity.NewIdempotencyKey("live", "hash", []byte(`{}`), time.Hour)))
+
+	deleted, err := s.idempotencyKeyDB.DeleteExpired(time.Now())
+	s.Nil(err)
+	s.Equal(int64(1), deleted)
+
+	_, err = s.idempotencyKeyDB.Find("live")
+	s.Nil(err)
+}
+
+func TestIdempotencyKeyDBTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyKeyDBTestSuite))
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+type StatementDB struct {
+	DB DBTX
+}
+
+func NewStatementDB(db DBTX) *StatementDB {
+	return &StatementDB{DB: db}
+}
+
+func (s *StatementDB) Append(line *entity.StatementLine) error {
+	var count int
+	err := s.DB.QueryRow("SELECT COUNT(*) FROM account_statements WHERE account_id = ? AND transaction_id = ?", line.AccountID, line.TransactionID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	stmt, err := s.DB.Prepare("INSERT INTO account_statements (id, account_id, transaction_id, direction, counterparty_account_id, amount, balance, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(line.ID, line.AccountID, line.TransactionID, line.Direction, line.CounterpartyAccountID, line.Amount, line.Balance, line.CreatedAt)
+	return err
+}
+
+func (s *StatementDB) FindByAccountID(accountID string, filter gateway.StatementFilter) ([]*entity.StatementLine, error) {
+	conditions := []string{"account_id = ?"}
+	args := []interface{}{accountID}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To)
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+	query := "SELECT id, account_id, transaction_id, direction, counterparty_account_id, amount, balance, created_at FROM account_statements WHERE " +
+		strings.Join(conditions, " AND ") + " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []*entity.StatementLine
+	for rows.Next() {
+		line := &entity.StatementLine{}
+		err = rows.Scan(&line.ID, &line.AccountID, &line.TransactionID, &line.Direction, &line.CounterpartyAccountID, &line.Amount, &line.Balance, &line.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, rows.Err()
+}
+
+func (s *StatementDB) FindByID(id string) (*entity.StatementLine, error) {
+	line := &entity.StatementLine{}
+	err := s.DB.QueryRow("SELECT id, account_id, transaction_id, direction, counterparty_account_id, amount, balance, created_at FROM account_statements WHERE id = ?", id).
+		Scan(&line.ID, &line.AccountID, &line.TransactionID, &line.Direction, &line.CounterpartyAccountID, &line.Amount, &line.Balance, &line.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return line, nil
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatementDBTestSuite struct {
+	suite.Suite
+	db          *sql.DB
+	statementDB *StatementDB
+}
+
+func (s *StatementDBTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	s.db = db
+	_, err = s.db.Exec("CREATE TABLE account_statements (id varchar(255), account_id varchar(255), transaction_id varchar(255), direction varchar(16), counterparty_account_id varchar(255), amount float, balance float, created_at datetime)")
+	s.Nil(err)
+	s.statementDB = NewStatementDB(db)
+}
+
+func (s *StatementDBTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *StatementDBTestSuite) TestAppend_IgnoresDuplicates() {
+	now := time.Now().UTC()
+	lines := entity.NewStatementLines("tx-1", "from", "to", 10, 90, 10, now)
+	s.Nil(s.statementDB.Append(lines[0]))
+	s.Nil(s.statementDB.Append(lines[0]))
+	replayed := entity.NewStatementLines("tx-1", "from", "to", 10, 90, 10, now)
+	s.Nil(s.statementDB.Append(replayed[0]))
+	s.Nil(s.statementDB.Append(lines[1]))
+
+	var count int
+	s.Nil(s.db.QueryRow("SELECT COUNT(*) FROM account_statements").Scan(&count))
+	s.Equal(2, count)
+
+	line, err := s.statementDB.FindByID(lines[1].ID)
+	s.Nil(err)
+	s.Equal("to", line.AccountID)
+	s.Equal(entity.StatementCredit, line.Direction)
+	s.Equal("from", line.CounterpartyAccountID)
+	s.Equal(10.0, line.Balance)
+}
+
+func (s *StatementDBTestSuite) TestFindByAccountID() {
+	start := time.Now().UTC()
+	var debits []*entity.StatementLine
+	for i := 0; i < 5; i++ {
+		lines := entity.NewStatementLines(fmt.Sprintf("tx-%d", i), "from", "to", 10, float64(90-10*i), float64(10+10*i), start.Add(time.Duration(i)*time.Minute))
+		s.Nil(s.statementDB.Append(lines[0]))
+		s.Nil(s.statementDB.Append(lines[1]))
+		debits = append(debits, lines[0])
+	}
+
+	page, err := s.statementDB.FindByAccountID("from", gateway.StatementFilter{Limit: 2})
+	s.Nil(err)
+	s.Len(page, 2)
+	s.Equal(debits[4].ID, page[0].ID)
+	s.Equal(debits[3].ID, page[1].ID)
+
+	page, err = s.statementDB.FindByAccountID("from", gateway.StatementFilter{After: page[1], Limit: 2})
+	s.Nil(err)
+	s.Len(page, 2)
+	s.Equal(debits[2].ID, page[0].ID)
+	s.Equal(debits[1].ID, page[1].ID)
+
+	page, err = s.statementDB.FindByAccountID("from", gateway.StatementFilter{
+		From: start.Add(time.Minute),
+		To:   start.Add(3 * time.Minute),
+	})
+	s.Nil(err)
+	s.Len(page, 2)
+	s.Equal(debits[2].ID, page[0].ID)
+	s.Equal(debits[1].ID, page[1].ID)
+	s.Equal(70.0, page[0].Balance)
+}
+
+func TestStatementDBTestSuite(t *testing.T) {
+	suite.Run(t, new(StatementDBTestSuite))
+}
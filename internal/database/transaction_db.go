@@ -2,29 +2,167 @@ package database
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
 )
 
+const transactionColumns = "id, type, status, account_id_from, account_id_to, amount, reversed_amount, original_transaction_id, expires_at, captured_at, created_at"
+
+// settledAt is when a transaction moved its funds, as
+// entity.Transaction.SettledAt.
+const settledAt = "COALESCE(captured_at, created_at)"
+
 type TransactionDB struct {
-	DB *sql.DB
+	DB DBTX
+	// ForUpdate makes FindByID lock the row of the transaction with SELECT
+	// ... FOR UPDATE. A locking read sees the latest committed row and does
+	// not start the MySQL snapshot, so the accounts a use case locks after
+	// reading the transaction are read as they are once locked. It is left
+	// off for SQLite, which has no FOR UPDATE.
+	ForUpdate bool
 }
 
-func NewTransactionDB(db *sql.DB) *TransactionDB {
+func NewTransactionDB(db DBTX) *TransactionDB {
 	return &TransactionDB{DB: db}
 }
 
 func (t *TransactionDB) Create(transaction *entity.Transaction) error {
-	stmt, err := t.DB.Prepare("INSERT INTO transactions (id, account_id_from,account_id_to, amount, created_at) VALUES (?, ?, ?, ?, ?)")
+	stmt, err := t.DB.Prepare("INSERT INTO transactions (" + transactionColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(transaction.ID, transaction.AccountFrom.ID, transaction.AccountTo.ID, transaction.Amount, transaction.CreatedAt)
+	transactionType := transaction.Type
+	if transactionType == "" {
+		transactionType = entity.TransactionTransfer
+	}
+	status := transaction.Status
+	if status == "" {
+		status = entity.TransactionSettled
+	}
+	originalTransactionID := sql.NullString{String: transaction.OriginalTransactionID, Valid: transaction.OriginalTransactionID != ""}
+	expiresAt := sql.NullTime{Time: transaction.ExpiresAt, Valid: !transaction.ExpiresAt.IsZero()}
+	_, err = stmt.Exec(transaction.ID, transactionType, status, transaction.AccountFrom.ID, transaction.AccountTo.ID,
+		transaction.Amount, transaction.ReversedAmount, originalTransactionID, expiresAt, capturedAt(transaction), transaction.CreatedAt)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// UpdateStatus saves the status, reversed amount and capture time of
+// transaction, provided
+// the stored ones are still previousStatus and previousReversedAmount.
+// Otherwise another change won the race and gateway.ErrVersionConflict is
+// returned.
+func (t *TransactionDB) UpdateStatus(transaction *entity.Transaction, previousStatus string, previousReversedAmount float64) error {
+	result, err := t.DB.Exec("UPDATE transactions SET status = ?, reversed_amount = ?, captured_at = ? WHERE id = ? AND status = ? AND reversed_amount = ?",
+		transaction.Status, transaction.ReversedAmount, capturedAt(transaction), transaction.ID, previousStatus, previousReversedAmount)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return gateway.ErrVersionConflict
+	}
+	return nil
+}
+
+// FindByID returns a transaction whose accounts only carry their ID.
+func (t *TransactionDB) FindByID(id string) (*entity.Transaction, error) {
+	query := "SELECT " + transactionColumns + " FROM transactions WHERE id = ?"
+	if t.ForUpdate {
+		query += " FOR UPDATE"
+	}
+	return scanTransaction(t.DB.QueryRow(query, id))
+}
+
+// FindSince returns the transactions settled, or created when they are not
+// settled, at or after since, in that order. The accounts of each
+// transaction only carry their ID.
+func (t *TransactionDB) FindSince(since time.Time) ([]*entity.Transaction, error) {
+	rows, err := t.DB.Query("SELECT "+transactionColumns+" FROM transactions WHERE "+settledAt+" >= ? ORDER BY "+settledAt+", id", since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*entity.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, rows.Err()
+}
+
+// FindExpired returns up to limit authorized transactions whose hold expired
+// before now, oldest expiry first. The accounts of each transaction only
+// carry their ID.
+func (t *TransactionDB) FindExpired(now time.Time, limit int) ([]*entity.Transaction, error) {
+	rows, err := t.DB.Query("SELECT "+transactionColumns+" FROM transactions WHERE status = ? AND expires_at < ? ORDER BY expires_at, id LIMIT ?",
+		entity.TransactionAuthorized, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*entity.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, rows.Err()
+}
+
+// OutgoingUsage counts and sums the transfers and withdrawals sent by
+// accountID since since. Authorized transfers count, as they are meant to be
+// captured, and so do reversed ones; failed, voided and expired ones do not.
+func (t *TransactionDB) OutgoingUsage(accountID string, since time.Time) (entity.SpendingUsage, error) {
+	var usage entity.SpendingUsage
+	err := t.DB.QueryRow("SELECT COUNT(*), COALESCE(SUM(amount), 0) FROM transactions WHERE account_id_from = ? AND type IN (?, ?) AND status IN (?, ?, ?, ?) AND created_at >= ?",
+		accountID, entity.TransactionTransfer, entity.TransactionWithdrawal,
+		entity.TransactionAuthorized, entity.TransactionSettled, entity.TransactionPartiallyReversed, entity.TransactionReversed,
+		since).Scan(&usage.Count, &usage.Amount)
+	return usage, err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTransaction(row rowScanner) (*entity.Transaction, error) {
+	transaction := &entity.Transaction{
+		AccountFrom: &entity.Account{},
+		AccountTo:   &entity.Account{},
+	}
+	var originalTransactionID sql.NullString
+	var expiresAt, capturedAt sql.NullTime
+	err := row.Scan(&transaction.ID, &transaction.Type, &transaction.Status, &transaction.AccountFrom.ID, &transaction.AccountTo.ID,
+		&transaction.Amount, &transaction.ReversedAmount, &originalTransactionID, &expiresAt, &capturedAt, &transaction.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	transaction.OriginalTransactionID = originalTransactionID.String
+	transaction.ExpiresAt = expiresAt.Time
+	transaction.CapturedAt = capturedAt.Time
+	return transaction, nil
+}
+
+func capturedAt(transaction *entity.Transaction) sql.NullTime {
+	return sql.NullTime{Time: transaction.CapturedAt, Valid: !transaction.CapturedAt.IsZero()}
+}
@@ -3,8 +3,10 @@ package database
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/suite"
 )
@@ -23,9 +25,9 @@ func (s *TransactionDBTestSuite) SetupSuite() {
 	db, err := sql.Open("sqlite3", ":memory:")
 	s.Nil(err)
 	s.db = db
-	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), created_at, date updated_at date)")
-	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, created_at date)")
-	s.db.Exec("CREATE TABLE transactions (id varchar(255), account_id_from varchar(255), account_id_to varchar(255), amount float, created_at date)")
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at, date updated_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
 	s.transactionDB = NewTransactionDB(db)
 	s.client1, _ = entity.NewClient("John", "j@j.com")
 	s.client2, _ = entity.NewClient("Jane", "j2@j.com")
@@ -77,6 +79,135 @@ func (s *TransactionDBTestSuite) TestCreate() {
 	s.Equal(transaction.Amount, savedTransaction.Amount)
 }
 
+func (s *TransactionDBTestSuite) TestFindByID() {
+	transaction, _ := entity.NewTransaction(s.account1, s.account2, 30)
+	s.Nil(s.transactionDB.Create(transaction))
+
+	found, err := s.transactionDB.FindByID(transaction.ID)
+	s.Nil(err)
+	s.Equal(transaction.ID, found.ID)
+	s.Equal(s.account1.ID, found.AccountFrom.ID)
+	s.Equal(s.account2.ID, found.AccountTo.ID)
+	s.Equal(30.0, found.Amount)
+	s.Equal(entity.TransactionTransfer, found.Type)
+
+	_, err = s.transactionDB.FindByID("invalid_id")
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func (s *TransactionDBTestSuite) TestCreateDeposit() {
+	deposit, err := entity.NewDeposit(s.account2, s.account1, 50)
+	s.Nil(err)
+	s.Nil(s.transactionDB.Create(deposit))
+
+	found, err := s.transactionDB.FindByID(deposit.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionDeposit, found.Type)
+}
+
+func (s *TransactionDBTestSuite) TestReversal() {
+	transaction, _ := entity.NewTransaction(s.account1, s.account2, 40)
+	s.Nil(s.transactionDB.Create(transaction))
+	reversal, err := transaction.Reverse(10)
+	s.Nil(err)
+	s.Nil(s.transactionDB.Create(reversal))
+	s.Nil(s.transactionDB.UpdateStatus(transaction, entity.TransactionSettled, 0))
+
+	found, err := s.transactionDB.FindByID(transaction.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionPartiallyReversed, found.Status)
+	s.Equal(10.0, found.ReversedAmount)
+	s.Empty(found.OriginalTransactionID)
+
+	found, err = s.transactionDB.FindByID(reversal.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionReversal, found.Type)
+	s.Equal(entity.TransactionSettled, found.Status)
+	s.Equal(transaction.ID, found.OriginalTransactionID)
+
+	_, err = transaction.Reverse(10)
+	s.Nil(err)
+	s.ErrorIs(s.transactionDB.UpdateStatus(transaction, entity.TransactionSettled, 10), gateway.ErrVersionConflict)
+}
+
+func (s *TransactionDBTestSuite) TestFindExpired() {
+	now := time.Now()
+	expired := entity.NewPendingTransaction(s.account1, s.account2, 5)
+	s.Nil(expired.Authorize(now.Add(-time.Minute)))
+	active := entity.NewPendingTransaction(s.account1, s.account2, 5)
+	s.Nil(active.Authorize(now.Add(time.Hour)))
+	voided := entity.NewPendingTransaction(s.account1, s.account2, 5)
+	s.Nil(voided.Authorize(now.Add(-time.Minute)))
+	s.Nil(voided.Void())
+	for _, transaction := range []*entity.Transaction{expired, active, voided} {
+		s.Nil(s.transactionDB.Create(transaction))
+	}
+
+	transactions, err := s.transactionDB.FindExpired(now, 10)
+	s.Nil(err)
+	s.Len(transactions, 1)
+	s.Equal(expired.ID, transactions[0].ID)
+	s.Equal(entity.TransactionAuthorized, transactions[0].Status)
+	s.WithinDuration(expired.ExpiresAt, transactions[0].ExpiresAt, time.Second)
+
+	s.Nil(expired.Expire())
+	s.Nil(s.transactionDB.UpdateStatus(expired, entity.TransactionAuthorized, 0))
+	transactions, err = s.transactionDB.FindExpired(now, 10)
+	s.Nil(err)
+	s.Empty(transactions)
+}
+
+func (s *TransactionDBTestSuite) TestOutgoingUsage() {
+	since := time.Now()
+	old, _ := entity.NewTransaction(s.account1, s.account2, 1)
+	old.CreatedAt = since.Add(-time.Hour)
+	settled, _ := entity.NewTransaction(s.account1, s.account2, 10)
+	incoming, _ := entity.NewTransaction(s.account2, s.account1, 20)
+	authorized := entity.NewPendingTransaction(s.account1, s.account2, 30)
+	s.Nil(authorized.Authorize(since.Add(time.Hour)))
+	voided := entity.NewPendingTransaction(s.account1, s.account2, 40)
+	s.Nil(voided.Authorize(since.Add(time.Hour)))
+	s.Nil(voided.Void())
+	withdrawal, _ := entity.NewWithdrawal(s.account1, s.account2, 5)
+	deposit, _ := entity.NewDeposit(s.account1, s.account2, 50)
+	for _, transaction := range []*entity.Transaction{old, settled, incoming, authorized, voided, withdrawal, deposit} {
+		s.Nil(s.transactionDB.Create(transaction))
+	}
+
+	usage, err := s.transactionDB.OutgoingUsage(s.account1.ID, since)
+	s.Nil(err)
+	s.Equal(entity.SpendingUsage{Amount: 45, Count: 3}, usage)
+}
+
+func (s *TransactionDBTestSuite) TestFindSince() {
+	since := time.Now()
+	first, _ := entity.NewTransaction(s.account1, s.account2, 10)
+	second, _ := entity.NewTransaction(s.account2, s.account1, 20)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	s.Nil(s.transactionDB.Create(second))
+	s.Nil(s.transactionDB.Create(first))
+
+	captured := entity.NewPendingTransaction(s.account1, s.account2, 5)
+	captured.CreatedAt = since.Add(-time.Hour)
+	s.Nil(captured.Authorize(since.Add(time.Hour)))
+	s.Nil(s.transactionDB.Create(captured))
+	s.Nil(captured.Capture())
+	captured.CapturedAt = first.CreatedAt.Add(2 * time.Second)
+	s.Nil(s.transactionDB.UpdateStatus(captured, entity.TransactionAuthorized, 0))
+
+	transactions, err := s.transactionDB.FindSince(since)
+	s.Nil(err)
+	s.Len(transactions, 3)
+	s.Equal(first.ID, transactions[0].ID)
+	s.Equal(s.account1.ID, transactions[0].AccountFrom.ID)
+	s.Equal(s.account2.ID, transactions[0].AccountTo.ID)
+	s.Equal(10.0, transactions[0].Amount)
+	s.Equal(second.ID, transactions[1].ID)
+	s.Equal(captured.ID, transactions[2].ID)
+	s.WithinDuration(captured.CapturedAt, transactions[2].CapturedAt, time.Millisecond)
+	s.True(transactions[0].CapturedAt.IsZero())
+}
+
 func TestTransactionDBTestSuite(t *testing.T) {
 	suite.Run(t, new(TransactionDBTestSuite))
 }
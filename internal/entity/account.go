@@ -6,36 +6,263 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultCurrency is the currency of accounts opened without one, and of
+// the accounts opened before accounts had a currency.
+const DefaultCurrency = "BRL"
+
 type Account struct {
-	ID        string
-	Client    *Client
-	Balance   float64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID      string
+	Client  *Client
+	Balance float64
+	// Held is the part of Balance reserved by authorized transactions.
+	Held float64
+	// OverdraftLimit is how far below zero the balance of the account may
+	// go, on top of the credit line of its client.
+	OverdraftLimit float64
+	// CreditLineUsedElsewhere is the part of the credit line of the client
+	// drawn by its other accounts when this one was loaded.
+	CreditLineUsedElsewhere float64
+	// SpendingLimits caps the outgoing transfers of the account. The unset
+	// ones default to the spending limits of its client.
+	SpendingLimits SpendingLimits
+	// Status is one of the account statuses; FreezeMode is set while it
+	// is frozen, and StatusReason is the reason code of its last change.
+	Status       string
+	FreezeMode   string
+	StatusReason string
+	Currency     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// Version is the number of events in the history of the account,
+	// including the ones not persisted yet.
+	Version int
+	// BalanceVersion counts the changes to the balance of the account,
+	// including the ones not saved yet. It orders the balances the account
+	// is reported with.
+	BalanceVersion        int64
+	changes               []AccountEvent
+	pendingBalanceChanges int
 }
 
 func NewAccount(client *Client) *Account {
+	return NewAccountInCurrency(client, DefaultCurrency)
+}
+
+// NewAccountInCurrency opens an account holding currency, an ISO 4217 code.
+func NewAccountInCurrency(client *Client, currency string) *Account {
 	if client == nil {
 		return nil
 	}
 
-	account := &Account{
-		ID:        uuid.New().String(),
-		Client:    client,
-		Balance:   0,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	account := &Account{Client: client}
+	account.record(AccountOpened{
+		AccountID:  uuid.New().String(),
+		ClientID:   client.ID,
+		Currency:   currency,
+		OccurredAt: time.Now(),
+	})
+
+	return account
+}
+
+// RebuildAccount restores an account from an optional snapshot and the events
+// that happened after it.
+func RebuildAccount(snapshot *AccountSnapshot, history []AccountEvent) *Account {
+	account := &Account{}
+	if snapshot != nil {
+		account = &Account{
+			ID:        snapshot.ID,
+			Client:    &Client{ID: snapshot.ClientID},
+			Balance:   snapshot.Balance,
+			Held:      snapshot.Held,
+			Currency:  snapshot.Currency,
+			CreatedAt: snapshot.CreatedAt,
+			UpdatedAt: snapshot.UpdatedAt,
+			Version:   snapshot.Version,
+		}
+		if account.Currency == "" {
+			account.Currency = DefaultCurrency
+		}
+	}
+
+	for _, event := range history {
+		account.apply(event)
 	}
 
 	return account
 }
 
+// ImportAccount starts the history of an account persisted before the event
+// store existed, opening it with its current balance.
+func ImportAccount(account *Account) *Account {
+	imported := &Account{
+		Client:                  account.Client,
+		OverdraftLimit:          account.OverdraftLimit,
+		CreditLineUsedElsewhere: account.CreditLineUsedElsewhere,
+		BalanceVersion:          account.BalanceVersion,
+		SpendingLimits:          account.SpendingLimits,
+		Status:                  account.Status,
+		FreezeMode:              account.FreezeMode,
+		StatusReason:            account.StatusReason,
+	}
+	imported.record(AccountOpened{
+		AccountID:      account.ID,
+		ClientID:       account.Client.ID,
+		OpeningBalance: account.Balance,
+		OpeningHeld:    account.Held,
+		Currency:       account.Currency,
+		OccurredAt:     account.CreatedAt,
+	})
+
+	return imported
+}
+
 func (a *Account) Credit(amount float64) {
-	a.Balance += amount
-	a.UpdatedAt = time.Now()
+	a.record(AccountCredited{AccountID: a.ID, Amount: amount, OccurredAt: time.Now()})
 }
 
 func (a *Account) Debit(amount float64) {
-	a.Balance -= amount
-	a.UpdatedAt = time.Now()
+	a.record(AccountDebited{AccountID: a.ID, Amount: amount, OccurredAt: time.Now()})
+}
+
+// Hold reserves amount of the balance for the authorized transaction
+// transactionID. Held funds are still part of the balance, but no longer
+// available.
+func (a *Account) Hold(transactionID string, amount float64) {
+	a.record(AccountFundsHeld{AccountID: a.ID, TransactionID: transactionID, Amount: amount, OccurredAt: time.Now()})
+}
+
+// Release gives back the funds held for transactionID.
+func (a *Account) Release(transactionID string, amount float64) {
+	a.record(AccountFundsReleased{AccountID: a.ID, TransactionID: transactionID, Amount: amount, OccurredAt: time.Now()})
+}
+
+// AvailableBalance is the part of the balance that is not held.
+func (a *Account) AvailableBalance() float64 {
+	return a.Balance - a.Held
+}
+
+// Limit is how far below zero the balance of the account may go: its
+// overdraft limit plus what its other accounts left of the credit line of
+// its client.
+func (a *Account) Limit() float64 {
+	return a.OverdraftLimit + a.AvailableCreditLine()
+}
+
+// AvailableCreditLine is the part of the credit line of the client that the
+// other accounts of the client do not use.
+func (a *Account) AvailableCreditLine() float64 {
+	if a.Client == nil {
+		return 0
+	}
+	return max(0, a.Client.CreditLine-a.CreditLineUsedElsewhere)
+}
+
+// CreditLineUsed is the part of the credit line of the client the account
+// draws: how far its available balance is below zero past its own overdraft
+// limit.
+func (a *Account) CreditLineUsed() float64 {
+	return max(0, -a.AvailableBalance()-a.OverdraftLimit)
+}
+
+// SpendableBalance is the available balance plus the limit of the account,
+// the most a transfer from it may move.
+func (a *Account) SpendableBalance() float64 {
+	return a.AvailableBalance() + a.Limit()
+}
+
+// EffectiveSpendingLimits are the spending limits of the account, with the
+// unset ones taken from its client.
+func (a *Account) EffectiveSpendingLimits() SpendingLimits {
+	if a.Client == nil {
+		return a.SpendingLimits
+	}
+	return a.SpendingLimits.Or(a.Client.SpendingLimits)
+}
+
+// Changes returns the events recorded since the account was loaded or since
+// the last ClearChanges.
+func (a *Account) Changes() []AccountEvent {
+	return a.changes
+}
+
+// ClearChanges marks the recorded events as persisted.
+func (a *Account) ClearChanges() {
+	a.changes = nil
+}
+
+// PendingBalanceChanges is the number of changes to the balance of the
+// account since it was loaded or since the last MarkBalanceSaved.
+func (a *Account) PendingBalanceChanges() int {
+	return a.pendingBalanceChanges
+}
+
+// MarkBalanceSaved marks the changes to the balance as saved, with version
+// the stored balance version.
+func (a *Account) MarkBalanceSaved(version int64) {
+	a.BalanceVersion = version
+	a.pendingBalanceChanges = 0
+}
+
+// PersistedVersion is the version of the account before its pending changes,
+// which is the version an event store append expects.
+func (a *Account) PersistedVersion() int {
+	return a.Version - len(a.changes)
+}
+
+func (a *Account) Snapshot() AccountSnapshot {
+	return AccountSnapshot{
+		ID:        a.ID,
+		ClientID:  a.Client.ID,
+		Balance:   a.Balance,
+		Held:      a.Held,
+		Currency:  a.Currency,
+		Version:   a.Version,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func (a *Account) record(event AccountEvent) {
+	a.apply(event)
+	a.changes = append(a.changes, event)
+	switch event.(type) {
+	case AccountCredited, AccountDebited:
+		a.BalanceVersion++
+		a.pendingBalanceChanges++
+	}
+}
+
+func (a *Account) apply(event AccountEvent) {
+	switch e := event.(type) {
+	case AccountOpened:
+		a.ID = e.AccountID
+		if a.Client == nil || a.Client.ID != e.ClientID {
+			a.Client = &Client{ID: e.ClientID}
+		}
+		if a.Status == "" {
+			a.Status = AccountActive
+		}
+		a.Balance = e.OpeningBalance
+		a.Held = e.OpeningHeld
+		a.Currency = e.Currency
+		if a.Currency == "" {
+			a.Currency = DefaultCurrency
+		}
+		a.CreatedAt = e.OccurredAt
+		a.UpdatedAt = e.OccurredAt
+	case AccountCredited:
+		a.Balance += e.Amount
+		a.UpdatedAt = e.OccurredAt
+	case AccountDebited:
+		a.Balance -= e.Amount
+		a.UpdatedAt = e.OccurredAt
+	case AccountFundsHeld:
+		a.Held += e.Amount
+		a.UpdatedAt = e.OccurredAt
+	case AccountFundsReleased:
+		a.Held -= e.Amount
+		a.UpdatedAt = e.OccurredAt
+	}
+	a.Version++
 }
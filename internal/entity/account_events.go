@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+// AccountEvent is a fact in the history of an Account. Replaying the history
+// of an account in order rebuilds its state.
+type AccountEvent interface {
+	GetName() string
+	GetDateTime() time.Time
+}
+
+type AccountOpened struct {
+	AccountID string `json:"account_id"`
+	ClientID  string `json:"client_id"`
+	// OpeningBalance is only set for accounts created before the event store
+	// existed, whose history starts from their balance at that time.
+	OpeningBalance float64 `json:"opening_balance"`
+	// OpeningHeld is the part of OpeningBalance held at that time.
+	OpeningHeld float64 `json:"opening_held,omitempty"`
+	// Currency is empty for accounts opened before accounts had a currency,
+	// which hold DefaultCurrency.
+	Currency   string    `json:"currency,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type AccountCredited struct {
+	AccountID  string    `json:"account_id"`
+	Amount     float64   `json:"amount"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type AccountDebited struct {
+	AccountID  string    `json:"account_id"`
+	Amount     float64   `json:"amount"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// AccountFundsHeld reserves Amount of the balance for an authorized
+// transaction, until it is captured, voided or expires.
+type AccountFundsHeld struct {
+	AccountID     string    `json:"account_id"`
+	TransactionID string    `json:"transaction_id"`
+	Amount        float64   `json:"amount"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+type AccountFundsReleased struct {
+	AccountID     string    `json:"account_id"`
+	TransactionID string    `json:"transaction_id"`
+	Amount        float64   `json:"amount"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+func (AccountOpened) GetName() string        { return "AccountOpened" }
+func (AccountCredited) GetName() string      { return "AccountCredited" }
+func (AccountDebited) GetName() string       { return "AccountDebited" }
+func (AccountFundsHeld) GetName() string     { return "AccountFundsHeld" }
+func (AccountFundsReleased) GetName() string { return "AccountFundsReleased" }
+
+func (e AccountOpened) GetDateTime() time.Time        { return e.OccurredAt }
+func (e AccountCredited) GetDateTime() time.Time      { return e.OccurredAt }
+func (e AccountDebited) GetDateTime() time.Time       { return e.OccurredAt }
+func (e AccountFundsHeld) GetDateTime() time.Time     { return e.OccurredAt }
+func (e AccountFundsReleased) GetDateTime() time.Time { return e.OccurredAt }
+
+// AccountSnapshot is the state of an Account after Version events, stored so
+// that loading an account does not replay its whole history.
+type AccountSnapshot struct {
+	ID        string    `json:"id"`
+	ClientID  string    `json:"client_id"`
+	Balance   float64   `json:"balance"`
+	Held      float64   `json:"held,omitempty"`
+	Currency  string    `json:"currency,omitempty"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
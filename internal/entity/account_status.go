@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Account statuses. Frozen accounts are blocked as their FreezeMode says;
+// closed accounts can neither send nor receive and never reopen.
+const (
+	AccountActive = "active"
+	AccountFrozen = "frozen"
+	AccountClosed = "closed"
+)
+
+// Freeze modes.
+const (
+	// FreezeOutgoing lets a frozen account receive but not send.
+	FreezeOutgoing = "outgoing"
+	// FreezeAll blocks a frozen account in both directions.
+	FreezeAll = "all"
+)
+
+// Reason codes of account status changes.
+const (
+	ReasonFraudSuspected   = "fraud_suspected"
+	ReasonComplianceReview = "compliance_review"
+	ReasonLegalOrder       = "legal_order"
+	ReasonCustomerRequest  = "customer_request"
+	ReasonResolved         = "resolved"
+	// ReasonClientDeleted closes the accounts of a deleted client.
+	ReasonClientDeleted = "client_deleted"
+	ReasonOther         = "other"
+)
+
+// FreezeModes and StatusReasons list the valid freeze modes and reason codes.
+var (
+	FreezeModes   = []string{FreezeOutgoing, FreezeAll}
+	StatusReasons = []string{ReasonFraudSuspected, ReasonComplianceReview, ReasonLegalOrder, ReasonCustomerRequest, ReasonResolved, ReasonClientDeleted, ReasonOther}
+)
+
+var (
+	ErrAccountFrozen            = errors.New("account is frozen")
+	ErrAccountClosed            = errors.New("account is closed")
+	ErrAccountNotEmpty          = errors.New("account balance is not zero")
+	ErrInvalidAccountTransition = errors.New("invalid account status transition")
+	ErrInvalidFreezeMode        = errors.New("invalid freeze mode")
+	ErrInvalidStatusReason      = errors.New("invalid status reason")
+)
+
+// AccountTransitionError is returned for a status change the account
+// lifecycle does not allow. It wraps ErrInvalidAccountTransition.
+type AccountTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *AccountTransitionError) Error() string {
+	return fmt.Sprintf("%v: %s to %s", ErrInvalidAccountTransition, e.From, e.To)
+}
+
+func (e *AccountTransitionError) Unwrap() error {
+	return ErrInvalidAccountTransition
+}
+
+var accountTransitions = map[string][]string{
+	AccountActive: {AccountFrozen, AccountClosed},
+	AccountFrozen: {AccountActive, AccountClosed},
+}
+
+// Freeze blocks the account as mode says, for reason.
+func (a *Account) Freeze(mode string, reason string) error {
+	if !slices.Contains(FreezeModes, mode) {
+		return ErrInvalidFreezeMode
+	}
+	if err := a.transition(AccountFrozen, reason); err != nil {
+		return err
+	}
+	a.FreezeMode = mode
+	return nil
+}
+
+// Unfreeze makes a frozen account active again.
+func (a *Account) Unfreeze(reason string) error {
+	return a.transition(AccountActive, reason)
+}
+
+// Close closes the account for good. Its balance must be zero, with no funds
+// held, in cents.
+func (a *Account) Close(reason string) error {
+	if cents(a.Balance) != 0 || cents(a.Held) != 0 {
+		return ErrAccountNotEmpty
+	}
+	return a.transition(AccountClosed, reason)
+}
+
+// checkCanSend fails when the status of the account keeps it from being
+// debited by a transaction.
+func (a *Account) checkCanSend() error {
+	switch a.status() {
+	case AccountFrozen:
+		return ErrAccountFrozen
+	case AccountClosed:
+		return ErrAccountClosed
+	}
+	return nil
+}
+
+// checkCanReceive fails when the status of the account keeps it from being
+// credited by a transaction.
+func (a *Account) checkCanReceive() error {
+	switch a.status() {
+	case AccountFrozen:
+		if a.FreezeMode == FreezeAll {
+			return ErrAccountFrozen
+		}
+	case AccountClosed:
+		return ErrAccountClosed
+	}
+	return nil
+}
+
+// status treats accounts loaded without a status as active.
+func (a *Account) status() string {
+	if a.Status == "" {
+		return AccountActive
+	}
+	return a.Status
+}
+
+func (a *Account) transition(status string, reason string) error {
+	if !slices.Contains(StatusReasons, reason) {
+		return ErrInvalidStatusReason
+	}
+	if !slices.Contains(accountTransitions[a.status()], status) {
+		return &AccountTransitionError{From: a.status(), To: status}
+	}
+	a.Status = status
+	a.FreezeMode = ""
+	a.StatusReason = reason
+	return nil
+}
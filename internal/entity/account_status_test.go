@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountLifecycle(t *testing.T) {
+	client, _ := NewClient("John", "j@j.com")
+	account := NewAccount(client)
+	assert.Equal(t, AccountActive, account.Status)
+
+	assert.ErrorIs(t, account.Freeze("incoming", ReasonFraudSuspected), ErrInvalidFreezeMode)
+	assert.ErrorIs(t, account.Freeze(FreezeAll, "bored"), ErrInvalidStatusReason)
+	assert.Nil(t, account.Freeze(FreezeAll, ReasonFraudSuspected))
+	assert.Equal(t, AccountFrozen, account.Status)
+	assert.Equal(t, FreezeAll, account.FreezeMode)
+	assert.Equal(t, ReasonFraudSuspected, account.StatusReason)
+	assert.Equal(t, &AccountTransitionError{From: AccountFrozen, To: AccountFrozen}, account.Freeze(FreezeOutgoing, ReasonOther))
+
+	assert.Nil(t, account.Unfreeze(ReasonResolved))
+	assert.Equal(t, AccountActive, account.Status)
+	assert.Empty(t, account.FreezeMode)
+
+	account.Credit(10)
+	assert.ErrorIs(t, account.Close(ReasonCustomerRequest), ErrAccountNotEmpty)
+	account.Debit(10)
+	assert.Nil(t, account.Close(ReasonCustomerRequest))
+	assert.Equal(t, AccountClosed, account.Status)
+	assert.ErrorIs(t, account.Unfreeze(ReasonResolved), ErrInvalidAccountTransition)
+}
+
+func TestCloseAccountWithRoundingResidue(t *testing.T) {
+	client, _ := NewClient("John", "j@j.com")
+	account := NewAccount(client)
+	account.Credit(0.1)
+	account.Credit(0.2)
+	account.Debit(0.3)
+	assert.NotZero(t, account.Balance)
+
+	assert.Nil(t, account.Close(ReasonCustomerRequest))
+}
+
+func TestCreateTransactionWithAccountStatus(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	account1 := NewAccount(client1)
+	account1.Credit(1000)
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+	account2.Credit(1000)
+
+	assert.Nil(t, account1.Freeze(FreezeOutgoing, ReasonFraudSuspected))
+	_, err := NewTransaction(account1, account2, 100)
+	assert.ErrorIs(t, err, ErrAccountFrozen)
+	_, err = NewTransaction(account2, account1, 100)
+	assert.Nil(t, err, "an account frozen for outgoing transfers still receives")
+
+	assert.Nil(t, account1.Unfreeze(ReasonResolved))
+	assert.Nil(t, account1.Freeze(FreezeAll, ReasonLegalOrder))
+	_, err = NewTransaction(account2, account1, 100)
+	assert.ErrorIs(t, err, ErrAccountFrozen)
+
+	account3 := NewAccount(client2)
+	assert.Nil(t, account3.Close(ReasonCustomerRequest))
+	_, err = NewTransaction(account2, account3, 100)
+	assert.ErrorIs(t, err, ErrAccountClosed)
+	assert.Equal(t, 900.0, account2.Balance)
+}
+
+func TestReverseFromAccountFrozenForOutgoing(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	account1 := NewAccount(client1)
+	account1.Credit(1000)
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+
+	transaction, err := NewTransaction(account1, account2, 100)
+	assert.Nil(t, err)
+	assert.Nil(t, account2.Freeze(FreezeOutgoing, ReasonFraudSuspected))
+
+	_, err = transaction.Reverse(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, account2.Balance)
+}
+
+func TestCaptureWithFrozenAccount(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	account1 := NewAccount(client1)
+	account1.Credit(1000)
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+
+	transaction := NewPendingTransaction(account1, account2, 100)
+	assert.Nil(t, transaction.Authorize(time.Now().Add(time.Hour)))
+	assert.Nil(t, account1.Freeze(FreezeOutgoing, ReasonFraudSuspected))
+
+	assert.ErrorIs(t, transaction.Capture(), ErrAccountFrozen)
+	assert.Equal(t, TransactionAuthorized, transaction.Status)
+	assert.Nil(t, transaction.Void())
+	assert.Equal(t, 1000.0, account1.AvailableBalance())
+}
@@ -32,3 +32,60 @@ func TestDebitAccount(t *testing.T) {
 	account.Debit(50)
 	assert.Equal(t, 50.0, account.Balance)
 }
+
+func TestRebuildAccount(t *testing.T) {
+	client, _ := NewClient("John Doe", "j@j.com")
+	account := NewAccount(client)
+	account.Credit(100)
+	account.Debit(30)
+	assert.Equal(t, 3, account.Version)
+	assert.Equal(t, 0, account.PersistedVersion())
+
+	rebuilt := RebuildAccount(nil, account.Changes())
+	assert.Equal(t, account.ID, rebuilt.ID)
+	assert.Equal(t, client.ID, rebuilt.Client.ID)
+	assert.Equal(t, 70.0, rebuilt.Balance)
+	assert.Equal(t, 3, rebuilt.Version)
+	assert.Empty(t, rebuilt.Changes())
+}
+
+func TestRebuildAccountFromSnapshot(t *testing.T) {
+	client, _ := NewClient("John Doe", "j@j.com")
+	account := NewAccount(client)
+	account.Credit(100)
+	snapshot := account.Snapshot()
+	account.ClearChanges()
+
+	account.Debit(40)
+	rebuilt := RebuildAccount(&snapshot, account.Changes())
+	assert.Equal(t, 60.0, rebuilt.Balance)
+	assert.Equal(t, 3, rebuilt.Version)
+}
+
+func TestImportAccount(t *testing.T) {
+	client, _ := NewClient("John Doe", "j@j.com")
+	legacy := &Account{ID: "legacy", Client: client, Balance: 500}
+
+	account := ImportAccount(legacy)
+	assert.Equal(t, "legacy", account.ID)
+	assert.Equal(t, 500.0, account.Balance)
+	assert.Equal(t, 0, account.PersistedVersion())
+	assert.Len(t, account.Changes(), 1)
+}
+
+func TestHoldAndReleaseFunds(t *testing.T) {
+	client, _ := NewClient("John Doe", "j@j.com")
+	account := NewAccount(client)
+	account.Credit(100)
+	account.Hold("t1", 30)
+	assert.Equal(t, 100.0, account.Balance)
+	assert.Equal(t, 70.0, account.AvailableBalance())
+
+	snapshot := account.Snapshot()
+	account.ClearChanges()
+	account.Release("t1", 30)
+	rebuilt := RebuildAccount(&snapshot, account.Changes())
+	assert.Equal(t, 0.0, rebuilt.Held)
+	assert.Equal(t, 100.0, rebuilt.AvailableBalance())
+	assert.Equal(t, 30.0, snapshot.Held)
+}
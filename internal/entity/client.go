@@ -9,14 +9,23 @@ import (
 
 var ErrInvalidName = errors.New("invalid name")
 var ErrInvalidEmail = errors.New("invalid email")
+var ErrClientHasFunds = errors.New("client has accounts with a non-zero balance")
 
 type Client struct {
-	ID        string
-	Name      string
-	Email     string
-	Accounts  []*Account
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID    string
+	Name  string
+	Email string
+	// CreditLine is shared by the accounts of the client: together they may
+	// go that far below zero past their own overdraft limits.
+	CreditLine float64
+	// SpendingLimits are the defaults of the spending limits of the accounts
+	// of the client.
+	SpendingLimits SpendingLimits
+	Accounts       []*Account
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// DeletedAt is set once the client is deleted.
+	DeletedAt time.Time
 }
 
 func NewClient(name string, email string) (*Client, error) {
@@ -55,6 +64,19 @@ func (c *Client) Update(name string, email string) error {
 	return nil
 }
 
+// Delete marks the client deleted. Every account of the client, added with
+// AddAccount, must have a zero balance and no funds held.
+func (c *Client) Delete() error {
+	for _, account := range c.Accounts {
+		if account.Balance != 0 || account.Held != 0 {
+			return ErrClientHasFunds
+		}
+	}
+	c.DeletedAt = time.Now()
+	c.UpdatedAt = c.DeletedAt
+	return nil
+}
+
 func (c *Client) AddAccount(account *Account) error {
 	if account.Client.ID != c.ID {
 		return errors.New("account does not belong to this client")
@@ -41,3 +41,22 @@ func TestAddAccountToClient(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(client.Accounts))
 }
+
+func TestDeleteClient(t *testing.T) {
+	client, _ := NewClient("John Doe", "j@j.com")
+	client.AddAccount(NewAccount(client))
+	err := client.Delete()
+	assert.Nil(t, err)
+	assert.False(t, client.DeletedAt.IsZero())
+	assert.Equal(t, client.DeletedAt, client.UpdatedAt)
+}
+
+func TestDeleteClientWithFunds(t *testing.T) {
+	client, _ := NewClient("John Doe", "j@j.com")
+	account := NewAccount(client)
+	account.Held = 10
+	client.AddAccount(account)
+	err := client.Delete()
+	assert.ErrorIs(t, err, ErrClientHasFunds)
+	assert.True(t, client.DeletedAt.IsZero())
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// IdempotencyKey remembers the response given to a request carrying an
+// Idempotency-Key header, so that a retry gets the same response instead of
+// repeating the operation.
+type IdempotencyKey struct {
+	Key         string
+	RequestHash string
+	Response    []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func NewIdempotencyKey(key string, requestHash string, response []byte, ttl time.Duration) *IdempotencyKey {
+	now := time.Now()
+	return &IdempotencyKey{
+		Key:         key,
+		RequestHash: requestHash,
+		Response:    response,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+func (k *IdempotencyKey) Expired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	key := NewIdempotencyKey("key", "hash", []byte(`{}`), time.Hour)
+
+	assert.Equal(t, "key", key.Key)
+	assert.Equal(t, "hash", key.RequestHash)
+	assert.Equal(t, time.Hour, key.ExpiresAt.Sub(key.CreatedAt))
+	assert.False(t, key.Expired(key.CreatedAt))
+	assert.False(t, key.Expired(key.ExpiresAt.Add(-time.Nanosecond)))
+	assert.True(t, key.Expired(key.ExpiresAt))
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrLimitExceeded = errors.New("spending limit exceeded")
+
+const (
+	LimitDailyAmount   = "daily_amount"
+	LimitDailyCount    = "daily_count"
+	LimitMonthlyAmount = "monthly_amount"
+	LimitMonthlyCount  = "monthly_count"
+)
+
+// SpendingLimits caps the money an account sends, by transfer or withdrawal,
+// per calendar day and per calendar month, by amount and by number of
+// transactions. A zero limit is unset.
+type SpendingLimits struct {
+	DailyAmount   float64 `json:"daily_amount"`
+	DailyCount    int     `json:"daily_count"`
+	MonthlyAmount float64 `json:"monthly_amount"`
+	MonthlyCount  int     `json:"monthly_count"`
+}
+
+// Or returns l with its unset limits taken from defaults.
+func (l SpendingLimits) Or(defaults SpendingLimits) SpendingLimits {
+	if l.DailyAmount == 0 {
+		l.DailyAmount = defaults.DailyAmount
+	}
+	if l.DailyCount == 0 {
+		l.DailyCount = defaults.DailyCount
+	}
+	if l.MonthlyAmount == 0 {
+		l.MonthlyAmount = defaults.MonthlyAmount
+	}
+	if l.MonthlyCount == 0 {
+		l.MonthlyCount = defaults.MonthlyCount
+	}
+	return l
+}
+
+// IsZero tells whether no limit is set.
+func (l SpendingLimits) IsZero() bool {
+	return l == SpendingLimits{}
+}
+
+// SpendingUsage is what an account sent in a period.
+type SpendingUsage struct {
+	Amount float64
+	Count  int
+}
+
+// LimitExceededError is returned for a transfer that would go over Limit,
+// one of the Limit constants, of which Remaining is left. It wraps
+// ErrLimitExceeded.
+type LimitExceededError struct {
+	Limit     string
+	Remaining float64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%v: %s, remaining %.2f", ErrLimitExceeded, e.Limit, e.Remaining)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Check returns a *LimitExceededError when sending amount on top of the
+// daily and monthly usage of an account goes over one of l. Amounts are
+// compared in cents.
+func (l SpendingLimits) Check(amount float64, daily SpendingUsage, monthly SpendingUsage) error {
+	if l.DailyCount > 0 && daily.Count+1 > l.DailyCount {
+		return &LimitExceededError{Limit: LimitDailyCount, Remaining: remaining(float64(l.DailyCount), float64(daily.Count))}
+	}
+	if l.DailyAmount > 0 && cents(daily.Amount)+cents(amount) > cents(l.DailyAmount) {
+		return &LimitExceededError{Limit: LimitDailyAmount, Remaining: remaining(l.DailyAmount, daily.Amount)}
+	}
+	if l.MonthlyCount > 0 && monthly.Count+1 > l.MonthlyCount {
+		return &LimitExceededError{Limit: LimitMonthlyCount, Remaining: remaining(float64(l.MonthlyCount), float64(monthly.Count))}
+	}
+	if l.MonthlyAmount > 0 && cents(monthly.Amount)+cents(amount) > cents(l.MonthlyAmount) {
+		return &LimitExceededError{Limit: LimitMonthlyAmount, Remaining: remaining(l.MonthlyAmount, monthly.Amount)}
+	}
+	return nil
+}
+
+func remaining(limit float64, used float64) float64 {
+	return fromCents(max(cents(limit)-cents(used), 0))
+}
+
+// SpendingPeriods returns the start of the day and of the month of now, in
+// its location.
+func SpendingPeriods(now time.Time) (day time.Time, month time.Time) {
+	year, m, d := now.Date()
+	return time.Date(year, m, d, 0, 0, 0, 0, now.Location()), time.Date(year, m, 1, 0, 0, 0, 0, now.Location())
+}
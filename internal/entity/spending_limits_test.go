@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpendingLimits_Check(t *testing.T) {
+	limits := SpendingLimits{DailyAmount: 500, DailyCount: 3, MonthlyAmount: 2000, MonthlyCount: 20}
+
+	assert.Nil(t, limits.Check(100, SpendingUsage{Amount: 400, Count: 2}, SpendingUsage{Amount: 400, Count: 2}))
+
+	err := limits.Check(100, SpendingUsage{Amount: 100, Count: 3}, SpendingUsage{Amount: 100, Count: 3})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, &LimitExceededError{Limit: LimitDailyCount, Remaining: 0}, err)
+
+	err = limits.Check(150, SpendingUsage{Amount: 400, Count: 1}, SpendingUsage{Amount: 400, Count: 1})
+	assert.Equal(t, &LimitExceededError{Limit: LimitDailyAmount, Remaining: 100}, err)
+
+	err = limits.Check(100, SpendingUsage{}, SpendingUsage{Amount: 1950, Count: 5})
+	assert.Equal(t, &LimitExceededError{Limit: LimitMonthlyAmount, Remaining: 50}, err)
+
+	err = limits.Check(100, SpendingUsage{}, SpendingUsage{Amount: 100, Count: 20})
+	assert.Equal(t, &LimitExceededError{Limit: LimitMonthlyCount, Remaining: 0}, err)
+	assert.Equal(t, "spending limit exceeded: monthly_count, remaining 0.00", err.Error())
+
+	small := SpendingLimits{DailyAmount: 0.3, MonthlyAmount: 0.3}
+	assert.Nil(t, small.Check(0.2, SpendingUsage{Amount: 0.1, Count: 1}, SpendingUsage{Amount: 0.1, Count: 1}))
+	err = small.Check(0.21, SpendingUsage{Amount: 0.1, Count: 1}, SpendingUsage{Amount: 0.1, Count: 1})
+	assert.Equal(t, &LimitExceededError{Limit: LimitDailyAmount, Remaining: 0.2}, err)
+
+	assert.Nil(t, SpendingLimits{}.Check(1e9, SpendingUsage{Amount: 1e9, Count: 1000}, SpendingUsage{}))
+}
+
+func TestAccount_EffectiveSpendingLimits(t *testing.T) {
+	client, _ := NewClient("John", "j@j.com")
+	client.SpendingLimits = SpendingLimits{DailyAmount: 500, DailyCount: 3, MonthlyAmount: 2000}
+	account := NewAccount(client)
+	account.SpendingLimits = SpendingLimits{DailyAmount: 100, MonthlyCount: 10}
+
+	assert.Equal(t, SpendingLimits{DailyAmount: 100, DailyCount: 3, MonthlyAmount: 2000, MonthlyCount: 10}, account.EffectiveSpendingLimits())
+}
+
+func TestSpendingPeriods(t *testing.T) {
+	day, month := SpendingPeriods(time.Date(2024, time.March, 15, 13, 45, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), day)
+	assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), month)
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatementDebit  = "debit"
+	StatementCredit = "credit"
+)
+
+// StatementLine is one movement of an account in its statement, with the
+// balance of the account right after it.
+type StatementLine struct {
+	ID                    string
+	AccountID             string
+	TransactionID         string
+	Direction             string
+	CounterpartyAccountID string
+	Amount                float64
+	Balance               float64
+	CreatedAt             time.Time
+}
+
+// NewStatementLines returns the debit line of the source account and the
+// credit line of the destination account of one transfer.
+func NewStatementLines(transactionID string, accountIDFrom string, accountIDTo string, amount float64, balanceFrom float64, balanceTo float64, occurredAt time.Time) []*StatementLine {
+	return []*StatementLine{
+		{
+			ID:                    uuid.New().String(),
+			AccountID:             accountIDFrom,
+			TransactionID:         transactionID,
+			Direction:             StatementDebit,
+			CounterpartyAccountID: accountIDTo,
+			Amount:                amount,
+			Balance:               balanceFrom,
+			CreatedAt:             occurredAt,
+		},
+		{
+			ID:                    uuid.New().String(),
+			AccountID:             accountIDTo,
+			TransactionID:         transactionID,
+			Direction:             StatementCredit,
+			CounterpartyAccountID: accountIDFrom,
+			Amount:                amount,
+			Balance:               balanceTo,
+			CreatedAt:             occurredAt,
+		},
+	}
+}
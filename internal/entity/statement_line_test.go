@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatementLines(t *testing.T) {
+	now := time.Now()
+	lines := NewStatementLines("tx", "from", "to", 100, 900, 1100, now)
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "from", lines[0].AccountID)
+	assert.Equal(t, StatementDebit, lines[0].Direction)
+	assert.Equal(t, "to", lines[0].CounterpartyAccountID)
+	assert.Equal(t, 900.0, lines[0].Balance)
+	assert.Equal(t, "to", lines[1].AccountID)
+	assert.Equal(t, StatementCredit, lines[1].Direction)
+	assert.Equal(t, "from", lines[1].CounterpartyAccountID)
+	assert.Equal(t, 1100.0, lines[1].Balance)
+	assert.NotEqual(t, lines[0].ID, lines[1].ID)
+	assert.Equal(t, now, lines[1].CreatedAt)
+}
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,17 +12,59 @@ import (
 var ErrInvalidAmount = errors.New("invalid amount")
 var ErrInsufficientFunds = errors.New("insufficient funds")
 
+const (
+	TransactionTransfer = "transfer"
+	// TransactionDeposit moves money from the settlement account, which
+	// stands for money entering the system, to a customer account.
+	TransactionDeposit = "deposit"
+	// TransactionWithdrawal moves money from a customer account to the
+	// settlement account.
+	TransactionWithdrawal = "withdrawal"
+	// TransactionReversal gives back all or part of another transaction,
+	// moving money from its recipient to its sender.
+	TransactionReversal = "reversal"
+)
+
 type Transaction struct {
 	ID          string
+	Type        string
+	Status      string
 	AccountFrom *Account
 	AccountTo   *Account
 	Amount      float64
-	CreatedAt   time.Time
+	// ReversedAmount is the part of Amount given back by reversals.
+	ReversedAmount float64
+	// OriginalTransactionID is the transaction a reversal gives back.
+	OriginalTransactionID string
+	// ExpiresAt is when the hold of an authorized transaction expires.
+	ExpiresAt time.Time
+	// CapturedAt is when an authorized transaction was captured.
+	CapturedAt time.Time
+	CreatedAt  time.Time
+	// Policy is the transfer policy Validate enforces. Nil means
+	// DefaultTransferPolicy.
+	Policy *TransferPolicy
 }
 
 func NewTransaction(accountFrom *Account, accountTo *Account, amount float64) (*Transaction, error) {
+	return newTransaction(TransactionTransfer, accountFrom, accountTo, amount)
+}
+
+// NewDeposit credits account with money coming from the settlement account.
+func NewDeposit(settlement *Account, account *Account, amount float64) (*Transaction, error) {
+	return newTransaction(TransactionDeposit, settlement, account, amount)
+}
+
+// NewWithdrawal debits account with money leaving to the settlement account.
+func NewWithdrawal(account *Account, settlement *Account, amount float64) (*Transaction, error) {
+	return newTransaction(TransactionWithdrawal, account, settlement, amount)
+}
+
+func newTransaction(transactionType string, accountFrom *Account, accountTo *Account, amount float64) (*Transaction, error) {
 	transaction := &Transaction{
 		ID:          uuid.New().String(),
+		Type:        transactionType,
+		Status:      TransactionSettled,
 		AccountFrom: accountFrom,
 		AccountTo:   accountTo,
 		Amount:      amount,
@@ -40,13 +84,72 @@ func (t *Transaction) Validate() error {
 		return ErrInvalidAmount
 	}
 
-	if t.AccountFrom.Balance < t.Amount {
+	policy := DefaultTransferPolicy
+	if t.Policy != nil {
+		policy = *t.Policy
+	}
+	if t.Type == TransactionReversal {
+		// A reversal undoes a transaction the policy already allowed, so
+		// only its accounts are checked.
+		if t.AccountFrom == nil || t.AccountTo == nil {
+			return ErrMissingAccount
+		}
+	} else if err := policy.Check(t); err != nil {
+		return err
+	}
+	if err := t.checkAccountStatuses(); err != nil {
+		return err
+	}
+	if t.Type == TransactionReversal && policy.AllowNegativeReversals {
+		return nil
+	}
+
+	// The settlement account funds deposits from outside the system, so
+	// its balance is not checked.
+	if t.Type != TransactionDeposit && cents(t.AccountFrom.SpendableBalance()) < cents(t.Amount) {
 		return ErrInsufficientFunds
 	}
 	return nil
 }
 
+// checkAccountStatuses fails when the status of either account blocks t. A
+// reversal may still take money back from an account frozen for outgoing
+// transfers only, which is how the funds a compromised account received are
+// returned.
+func (t *Transaction) checkAccountStatuses() error {
+	err := t.AccountFrom.checkCanSend()
+	if err != nil && !(t.Type == TransactionReversal && t.AccountFrom.FreezeMode == FreezeOutgoing) {
+		return fmt.Errorf("%w: %s", err, t.AccountFrom.ID)
+	}
+	if err := t.AccountTo.checkCanReceive(); err != nil {
+		return fmt.Errorf("%w: %s", err, t.AccountTo.ID)
+	}
+	return nil
+}
+
+// OverdrewAccountFrom tells whether moving the funds of t took the balance
+// of its sender from zero or more to below zero. The settlement account is
+// always below zero, so deposits never overdraw it.
+func (t *Transaction) OverdrewAccountFrom() bool {
+	return t.Type != TransactionDeposit && t.AccountFrom.Balance < 0 && t.AccountFrom.Balance+t.Amount >= 0
+}
+
 func (t *Transaction) Commit() {
 	t.AccountFrom.Debit(t.Amount)
 	t.AccountTo.Credit(t.Amount)
 }
+
+// cents rounds amount to whole cents, so that amounts summed as float64 are
+// compared without their rounding errors.
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// wholeCents tells whether amount has no fractions of a cent.
+func wholeCents(amount float64) bool {
+	return math.Abs(amount*100-math.Round(amount*100)) < 1e-6
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
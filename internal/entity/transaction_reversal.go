@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTransactionNotReversible = errors.New("transaction cannot be reversed")
+	ErrReversalExceedsOriginal  = errors.New("reversal exceeds the original transaction")
+)
+
+// ReversalAmountError is returned for a reversal larger than what is left of
+// the original transaction. It wraps ErrReversalExceedsOriginal.
+type ReversalAmountError struct {
+	Amount    float64
+	Remaining float64
+}
+
+func (e *ReversalAmountError) Error() string {
+	return fmt.Sprintf("%v: %.2f, remaining %.2f", ErrReversalExceedsOriginal, e.Amount, e.Remaining)
+}
+
+func (e *ReversalAmountError) Unwrap() error {
+	return ErrReversalExceedsOriginal
+}
+
+// Reverse gives back amount of t, or all that is left of it when amount is
+// zero, through a new reversal transaction. amount must be whole cents. Only settled and partially
+// reversed transactions can be reversed; reversals cannot. Both accounts of t must be loaded
+// with their balances; they are updated along with the reversed amount and
+// status of t.
+func (t *Transaction) Reverse(amount float64) (*Transaction, error) {
+	if t.Type == TransactionReversal || !t.CanTransition(TransactionPartiallyReversed) {
+		return nil, ErrTransactionNotReversible
+	}
+
+	remaining := fromCents(cents(t.Amount) - cents(t.ReversedAmount))
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount < 0 || !wholeCents(amount) {
+		return nil, ErrInvalidAmount
+	}
+	if cents(amount) > cents(remaining) {
+		return nil, &ReversalAmountError{Amount: amount, Remaining: remaining}
+	}
+	amount = fromCents(cents(amount))
+
+	reversal := &Transaction{
+		ID:                    uuid.New().String(),
+		Type:                  TransactionReversal,
+		Status:                TransactionSettled,
+		AccountFrom:           t.AccountTo,
+		AccountTo:             t.AccountFrom,
+		Amount:                amount,
+		OriginalTransactionID: t.ID,
+		CreatedAt:             time.Now(),
+		Policy:                t.Policy,
+	}
+	if err := reversal.Validate(); err != nil {
+		return nil, err
+	}
+
+	reversal.Commit()
+	t.ReversedAmount = fromCents(cents(t.ReversedAmount) + cents(amount))
+	status := TransactionPartiallyReversed
+	if cents(t.ReversedAmount) >= cents(t.Amount) {
+		status = TransactionReversed
+	}
+	return reversal, t.transition(status)
+}
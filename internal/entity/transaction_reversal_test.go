@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newReversibleTransaction(t *testing.T) *Transaction {
+	client1, _ := NewClient("John", "j@j.com")
+	account1 := NewAccount(client1)
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+	account1.Credit(1000)
+
+	transaction, err := NewTransaction(account1, account2, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionSettled, transaction.Status)
+	return transaction
+}
+
+func TestReverseTransaction(t *testing.T) {
+	transaction := newReversibleTransaction(t)
+
+	reversal, err := transaction.Reverse(0)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionReversal, reversal.Type)
+	assert.Equal(t, transaction.ID, reversal.OriginalTransactionID)
+	assert.Same(t, transaction.AccountTo, reversal.AccountFrom)
+	assert.Same(t, transaction.AccountFrom, reversal.AccountTo)
+	assert.Equal(t, 100.0, reversal.Amount)
+	assert.Equal(t, 1000.0, transaction.AccountFrom.Balance)
+	assert.Equal(t, 0.0, transaction.AccountTo.Balance)
+	assert.Equal(t, 100.0, transaction.ReversedAmount)
+	assert.Equal(t, TransactionReversed, transaction.Status)
+}
+
+func TestPartiallyReverseTransaction(t *testing.T) {
+	transaction := newReversibleTransaction(t)
+
+	_, err := transaction.Reverse(30)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionPartiallyReversed, transaction.Status)
+	assert.Equal(t, 70.0, transaction.AccountTo.Balance)
+
+	reversal, err := transaction.Reverse(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 70.0, reversal.Amount)
+	assert.Equal(t, TransactionReversed, transaction.Status)
+}
+
+func TestReverseTransactionInCents(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	account1 := NewAccount(client1)
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+	account1.Credit(1)
+	transaction, err := NewTransaction(account1, account2, 0.3)
+	assert.Nil(t, err)
+
+	_, err = transaction.Reverse(0.1)
+	assert.Nil(t, err)
+	_, err = transaction.Reverse(0.2)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.3, transaction.ReversedAmount)
+	assert.Equal(t, TransactionReversed, transaction.Status)
+}
+
+func TestReverseTransactionRejectsFractionsOfCents(t *testing.T) {
+	transaction := newReversibleTransaction(t)
+
+	reversal, err := transaction.Reverse(0.004)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+	assert.Nil(t, reversal)
+	assert.Equal(t, 900.0, transaction.AccountFrom.Balance)
+	assert.Equal(t, 0.0, transaction.ReversedAmount)
+
+	_, err = transaction.Reverse(0.29)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.29, transaction.ReversedAmount)
+}
+
+func TestReverseTransactionExceedingOriginal(t *testing.T) {
+	transaction := newReversibleTransaction(t)
+	_, err := transaction.Reverse(60)
+	assert.Nil(t, err)
+
+	reversal, err := transaction.Reverse(50)
+	assert.Nil(t, reversal)
+	assert.ErrorIs(t, err, ErrReversalExceedsOriginal)
+	var amountErr *ReversalAmountError
+	assert.ErrorAs(t, err, &amountErr)
+	assert.Equal(t, 40.0, amountErr.Remaining)
+	assert.Equal(t, 60.0, transaction.ReversedAmount)
+	assert.Equal(t, 40.0, transaction.AccountTo.Balance)
+
+	_, err = transaction.Reverse(0)
+	assert.Nil(t, err)
+	_, err = transaction.Reverse(0)
+	assert.ErrorIs(t, err, ErrTransactionNotReversible)
+}
+
+func TestReverseTransactionWithInsufficientFunds(t *testing.T) {
+	transaction := newReversibleTransaction(t)
+	transaction.AccountTo.Debit(80)
+
+	reversal, err := transaction.Reverse(0)
+	assert.Nil(t, reversal)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	assert.Equal(t, TransactionSettled, transaction.Status)
+
+	transaction.Policy = &TransferPolicy{AllowNegativeReversals: true}
+	reversal, err = transaction.Reverse(0)
+	assert.Nil(t, err)
+	assert.Equal(t, -80.0, transaction.AccountTo.Balance)
+	assert.Equal(t, TransactionReversed, transaction.Status)
+}
+
+func TestReverseReversal(t *testing.T) {
+	transaction := newReversibleTransaction(t)
+	reversal, _ := transaction.Reverse(0)
+
+	_, err := reversal.Reverse(0)
+	assert.ErrorIs(t, err, ErrTransactionNotReversible)
+}
+
+func TestReversalSkipsTransferRules(t *testing.T) {
+	transaction := newReversibleTransaction(t)
+	transaction.Policy = &TransferPolicy{MinAmount: 50}
+
+	_, err := transaction.Reverse(10)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transaction statuses. Transfers created by NewTransaction are settled
+// right away; two-phase transfers start pending and are authorized, placing
+// a hold on the sender, before being captured.
+const (
+	TransactionPending           = "pending"
+	TransactionAuthorized        = "authorized"
+	TransactionSettled           = "settled"
+	TransactionFailed            = "failed"
+	TransactionVoided            = "voided"
+	TransactionExpired           = "expired"
+	TransactionPartiallyReversed = "partially_reversed"
+	TransactionReversed          = "reversed"
+)
+
+var (
+	ErrInvalidTransition    = errors.New("invalid transaction status transition")
+	ErrAuthorizationExpired = errors.New("authorization has expired")
+)
+
+// TransitionError is returned for a status change the state machine does not
+// allow. It wraps ErrInvalidTransition.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%v: %s to %s", ErrInvalidTransition, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+var transactionTransitions = map[string][]string{
+	TransactionPending:           {TransactionAuthorized, TransactionFailed},
+	TransactionAuthorized:        {TransactionSettled, TransactionVoided, TransactionExpired},
+	TransactionSettled:           {TransactionPartiallyReversed, TransactionReversed},
+	TransactionPartiallyReversed: {TransactionPartiallyReversed, TransactionReversed},
+}
+
+// NewPendingTransaction returns a transfer waiting for Authorize. It moves no
+// money until it is captured.
+func NewPendingTransaction(accountFrom *Account, accountTo *Account, amount float64) *Transaction {
+	return &Transaction{
+		ID:          uuid.New().String(),
+		Type:        TransactionTransfer,
+		Status:      TransactionPending,
+		AccountFrom: accountFrom,
+		AccountTo:   accountTo,
+		Amount:      amount,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// CanTransition tells whether the status of t may change to status.
+func (t *Transaction) CanTransition(status string) bool {
+	return slices.Contains(transactionTransitions[t.Status], status)
+}
+
+func (t *Transaction) transition(status string) error {
+	if !t.CanTransition(status) {
+		return &TransitionError{From: t.Status, To: status}
+	}
+	t.Status = status
+	return nil
+}
+
+// MovedFunds tells whether t has debited and credited its accounts, which
+// authorized, voided, expired and failed transactions have not.
+func (t *Transaction) MovedFunds() bool {
+	switch t.Status {
+	case TransactionSettled, TransactionPartiallyReversed, TransactionReversed, "":
+		return true
+	}
+	return false
+}
+
+// SettledAt is when t moved its funds: when it was captured, for a
+// transaction that was authorized first, or else when it was created.
+func (t *Transaction) SettledAt() time.Time {
+	if !t.CapturedAt.IsZero() {
+		return t.CapturedAt
+	}
+	return t.CreatedAt
+}
+
+// Authorize validates a pending transaction and holds its amount on the
+// sender until expiresAt. When validation fails, t moves to failed and the
+// validation error is returned.
+func (t *Transaction) Authorize(expiresAt time.Time) error {
+	if !t.CanTransition(TransactionAuthorized) {
+		return &TransitionError{From: t.Status, To: TransactionAuthorized}
+	}
+	if err := t.Validate(); err != nil {
+		t.Status = TransactionFailed
+		return err
+	}
+
+	t.AccountFrom.Hold(t.ID, t.Amount)
+	t.ExpiresAt = expiresAt
+	return t.transition(TransactionAuthorized)
+}
+
+// Fail refuses a pending transaction for a reason found outside of it, such
+// as a spending limit of the sender.
+func (t *Transaction) Fail() error {
+	return t.transition(TransactionFailed)
+}
+
+// Capture settles an authorized transaction: the hold is released and the
+// amount moves from the sender to the recipient. It fails while the status of
+// either account blocks the transfer; the authorization can still be voided.
+func (t *Transaction) Capture() error {
+	if t.Status == TransactionAuthorized && !t.ExpiresAt.IsZero() && time.Now().After(t.ExpiresAt) {
+		return ErrAuthorizationExpired
+	}
+	if t.Status == TransactionAuthorized {
+		if err := t.checkAccountStatuses(); err != nil {
+			return err
+		}
+	}
+	if err := t.transition(TransactionSettled); err != nil {
+		return err
+	}
+
+	t.AccountFrom.Release(t.ID, t.Amount)
+	t.Commit()
+	t.CapturedAt = time.Now()
+	return nil
+}
+
+// Void cancels an authorized transaction, releasing its hold.
+func (t *Transaction) Void() error {
+	return t.release(TransactionVoided)
+}
+
+// Expire releases the hold of an authorized transaction past its ExpiresAt.
+func (t *Transaction) Expire() error {
+	return t.release(TransactionExpired)
+}
+
+func (t *Transaction) release(status string) error {
+	if err := t.transition(status); err != nil {
+		return err
+	}
+
+	t.AccountFrom.Release(t.ID, t.Amount)
+	return nil
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPendingTransaction(amount float64) *Transaction {
+	client1, _ := NewClient("John", "j@j.com")
+	account1 := NewAccount(client1)
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+	account1.Credit(100)
+
+	return NewPendingTransaction(account1, account2, amount)
+}
+
+func TestAuthorizeAndCaptureTransaction(t *testing.T) {
+	transaction := newPendingTransaction(60)
+	assert.Equal(t, TransactionPending, transaction.Status)
+
+	expiresAt := time.Now().Add(time.Hour)
+	assert.Nil(t, transaction.Authorize(expiresAt))
+	assert.Equal(t, TransactionAuthorized, transaction.Status)
+	assert.Equal(t, expiresAt, transaction.ExpiresAt)
+	assert.Equal(t, 100.0, transaction.AccountFrom.Balance)
+	assert.Equal(t, 40.0, transaction.AccountFrom.AvailableBalance())
+	assert.Equal(t, 0.0, transaction.AccountTo.Balance)
+
+	assert.Nil(t, transaction.Capture())
+	assert.Equal(t, TransactionSettled, transaction.Status)
+	assert.Equal(t, 40.0, transaction.AccountFrom.Balance)
+	assert.Equal(t, 40.0, transaction.AccountFrom.AvailableBalance())
+	assert.Equal(t, 60.0, transaction.AccountTo.Balance)
+}
+
+func TestAuthorizeTransactionOverAvailableBalance(t *testing.T) {
+	first := newPendingTransaction(60)
+	assert.Nil(t, first.Authorize(time.Now().Add(time.Hour)))
+
+	second := NewPendingTransaction(first.AccountFrom, first.AccountTo, 60)
+	err := second.Authorize(time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	assert.Equal(t, TransactionFailed, second.Status)
+	assert.Equal(t, 60.0, first.AccountFrom.Held)
+
+	_, err = NewTransaction(first.AccountFrom, first.AccountTo, 60)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestVoidTransaction(t *testing.T) {
+	transaction := newPendingTransaction(60)
+	assert.Nil(t, transaction.Authorize(time.Now().Add(time.Hour)))
+
+	assert.Nil(t, transaction.Void())
+	assert.Equal(t, TransactionVoided, transaction.Status)
+	assert.Equal(t, 100.0, transaction.AccountFrom.AvailableBalance())
+
+	err := transaction.Capture()
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+	var transitionErr *TransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, TransactionVoided, transitionErr.From)
+	assert.Equal(t, TransactionSettled, transitionErr.To)
+	assert.Equal(t, 100.0, transaction.AccountFrom.Balance)
+}
+
+func TestExpireTransaction(t *testing.T) {
+	transaction := newPendingTransaction(60)
+	assert.Nil(t, transaction.Authorize(time.Now().Add(-time.Second)))
+
+	assert.ErrorIs(t, transaction.Capture(), ErrAuthorizationExpired)
+	assert.Nil(t, transaction.Expire())
+	assert.Equal(t, TransactionExpired, transaction.Status)
+	assert.Equal(t, 100.0, transaction.AccountFrom.AvailableBalance())
+	assert.ErrorIs(t, transaction.Void(), ErrInvalidTransition)
+}
+
+func TestPendingTransactionCannotBeCapturedOrReversed(t *testing.T) {
+	transaction := newPendingTransaction(60)
+	assert.ErrorIs(t, transaction.Capture(), ErrInvalidTransition)
+
+	_, err := transaction.Reverse(0)
+	assert.ErrorIs(t, err, ErrTransactionNotReversible)
+	assert.False(t, transaction.MovedFunds())
+}
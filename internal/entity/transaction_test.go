@@ -55,3 +55,84 @@ func TestCreateTransactionWithInvalidAmount(t *testing.T) {
 	assert.Equal(t, 1000.0, account1.Balance)
 	assert.Equal(t, 1000.0, account2.Balance)
 }
+
+func TestCreateDeposit(t *testing.T) {
+	client, _ := NewClient("John", "j@j.com")
+	settlement := NewAccount(client)
+	account := NewAccount(client)
+
+	deposit, err := NewDeposit(settlement, account, 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionDeposit, deposit.Type)
+	assert.Equal(t, 100.0, account.Balance)
+	assert.Equal(t, -100.0, settlement.Balance)
+}
+
+func TestCreateWithdrawal(t *testing.T) {
+	client, _ := NewClient("John", "j@j.com")
+	settlement := NewAccount(client)
+	account := NewAccount(client)
+	account.Credit(100)
+
+	_, err := NewWithdrawal(account, settlement, 150)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	withdrawal, err := NewWithdrawal(account, settlement, 60)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionWithdrawal, withdrawal.Type)
+	assert.Equal(t, 40.0, account.Balance)
+	assert.Equal(t, 60.0, settlement.Balance)
+}
+
+func TestCreateTransactionWithinLimit(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	client1.CreditLine = 200
+	account1 := NewAccount(client1)
+	account1.OverdraftLimit = 300
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+
+	account1.Credit(100)
+	assert.Equal(t, 500.0, account1.Limit())
+	assert.Equal(t, 600.0, account1.SpendableBalance())
+
+	transaction, err := NewTransaction(account1, account2, 50)
+	assert.Nil(t, err)
+	assert.False(t, transaction.OverdrewAccountFrom())
+
+	transaction, err = NewTransaction(account1, account2, 300)
+	assert.Nil(t, err)
+	assert.True(t, transaction.OverdrewAccountFrom())
+	assert.Equal(t, -250.0, account1.Balance)
+
+	transaction, err = NewTransaction(account1, account2, 200)
+	assert.Nil(t, err)
+	assert.False(t, transaction.OverdrewAccountFrom())
+
+	_, err = NewTransaction(account1, account2, 100)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	assert.Equal(t, -450.0, account1.Balance)
+}
+
+func TestCreateTransactionSharesCreditLine(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	client1.CreditLine = 200
+	account1 := NewAccount(client1)
+	account1.OverdraftLimit = 50
+	client2, _ := NewClient("Jane", "j@j2.com")
+	account2 := NewAccount(client2)
+
+	_, err := NewTransaction(account1, account2, 170)
+	assert.Nil(t, err)
+	assert.Equal(t, 120.0, account1.CreditLineUsed())
+
+	sibling := NewAccount(client1)
+	sibling.CreditLineUsedElsewhere = account1.CreditLineUsed()
+	assert.Equal(t, 80.0, sibling.AvailableCreditLine())
+	_, err = NewTransaction(sibling, account2, 90)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	_, err = NewTransaction(sibling, account2, 80)
+	assert.Nil(t, err)
+	assert.Equal(t, 80.0, sibling.CreditLineUsed())
+}
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrMissingAccount         = errors.New("transaction account is missing")
+	ErrSelfTransfer           = errors.New("cannot transfer to the same account")
+	ErrAmountBelowMinimum     = errors.New("amount is below the minimum")
+	ErrAmountAboveMaximum     = errors.New("amount is above the maximum")
+	ErrCurrencyPairNotAllowed = errors.New("currency pair is not allowed")
+)
+
+// AmountLimitError is returned for an amount outside the limits of the
+// transfer policy. It wraps ErrAmountBelowMinimum or ErrAmountAboveMaximum.
+type AmountLimitError struct {
+	Amount float64
+	Limit  float64
+	Err    error
+}
+
+func (e *AmountLimitError) Error() string {
+	return fmt.Sprintf("%v: %.2f, limit %.2f", e.Err, e.Amount, e.Limit)
+}
+
+func (e *AmountLimitError) Unwrap() error {
+	return e.Err
+}
+
+// CurrencyPairError is returned for a transfer between currencies the
+// transfer policy does not allow. It wraps ErrCurrencyPairNotAllowed.
+type CurrencyPairError struct {
+	From string
+	To   string
+}
+
+func (e *CurrencyPairError) Error() string {
+	return fmt.Sprintf("%v: %s to %s", ErrCurrencyPairNotAllowed, e.From, e.To)
+}
+
+func (e *CurrencyPairError) Unwrap() error {
+	return ErrCurrencyPairNotAllowed
+}
+
+type CurrencyPair struct {
+	From string
+	To   string
+}
+
+// TransferRule checks one business rule of a transaction. It is only run on
+// transactions with both accounts set.
+type TransferRule func(t *Transaction) error
+
+// TransferPolicy holds the transfer rules of a deployment. Zero limits are
+// not enforced, and transfers between accounts of the same currency are
+// always allowed; AllowedCurrencyPairs lists the other allowed pairs.
+type TransferPolicy struct {
+	MinAmount            float64
+	MaxAmount            float64
+	AllowedCurrencyPairs []CurrencyPair
+	// AllowNegativeReversals lets a reversal debit a recipient that no
+	// longer has the funds, leaving it with a negative balance.
+	AllowNegativeReversals bool
+	// Rules are checked after the built-in ones, in order.
+	Rules []TransferRule
+}
+
+// DefaultTransferPolicy is the policy of transactions without one. It is
+// meant to be set once, at startup.
+var DefaultTransferPolicy = TransferPolicy{}
+
+// Check runs the rules of the policy in order and returns the first error.
+func (p TransferPolicy) Check(t *Transaction) error {
+	if t.AccountFrom == nil || t.AccountTo == nil {
+		return ErrMissingAccount
+	}
+
+	rules := []TransferRule{noSelfTransfer, p.amountLimits, p.currencyPairs}
+	for _, rule := range append(rules, p.Rules...) {
+		if err := rule(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func noSelfTransfer(t *Transaction) error {
+	if t.AccountFrom == t.AccountTo || t.AccountFrom.ID == t.AccountTo.ID {
+		return ErrSelfTransfer
+	}
+	return nil
+}
+
+func (p TransferPolicy) amountLimits(t *Transaction) error {
+	if p.MinAmount > 0 && t.Amount < p.MinAmount {
+		return &AmountLimitError{Amount: t.Amount, Limit: p.MinAmount, Err: ErrAmountBelowMinimum}
+	}
+	if p.MaxAmount > 0 && t.Amount > p.MaxAmount {
+		return &AmountLimitError{Amount: t.Amount, Limit: p.MaxAmount, Err: ErrAmountAboveMaximum}
+	}
+	return nil
+}
+
+func (p TransferPolicy) currencyPairs(t *Transaction) error {
+	pair := CurrencyPair{From: currencyOf(t.AccountFrom), To: currencyOf(t.AccountTo)}
+	if pair.From == pair.To {
+		return nil
+	}
+	for _, allowed := range p.AllowedCurrencyPairs {
+		if allowed == pair {
+			return nil
+		}
+	}
+	return &CurrencyPairError{From: pair.From, To: pair.To}
+}
+
+func currencyOf(account *Account) string {
+	if account.Currency == "" {
+		return DefaultCurrency
+	}
+	return account.Currency
+}
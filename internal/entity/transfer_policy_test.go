@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFundedAccount(currency string) *Account {
+	client, _ := NewClient("John", "j@j.com")
+	account := NewAccountInCurrency(client, currency)
+	account.Credit(10000)
+	return account
+}
+
+func TestTransaction_RejectsMissingAccounts(t *testing.T) {
+	_, err := NewTransaction(nil, newFundedAccount("BRL"), 100)
+	assert.ErrorIs(t, err, ErrMissingAccount)
+	_, err = NewTransaction(newFundedAccount("BRL"), nil, 100)
+	assert.ErrorIs(t, err, ErrMissingAccount)
+}
+
+func TestTransaction_RejectsSelfTransfer(t *testing.T) {
+	account := newFundedAccount("BRL")
+
+	_, err := NewTransaction(account, account, 100)
+	assert.ErrorIs(t, err, ErrSelfTransfer)
+
+	reloaded := &Account{ID: account.ID, Balance: account.Balance}
+	_, err = NewTransaction(account, reloaded, 100)
+	assert.ErrorIs(t, err, ErrSelfTransfer)
+	assert.Equal(t, 10000.0, account.Balance)
+}
+
+func TestTransferPolicy_AmountLimits(t *testing.T) {
+	policy := &TransferPolicy{MinAmount: 1, MaxAmount: 5000}
+	from, to := newFundedAccount("BRL"), newFundedAccount("BRL")
+
+	transaction := &Transaction{AccountFrom: from, AccountTo: to, Amount: 0.5, Policy: policy}
+	err := transaction.Validate()
+	var limitErr *AmountLimitError
+	assert.ErrorIs(t, err, ErrAmountBelowMinimum)
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, 1.0, limitErr.Limit)
+
+	transaction.Amount = 5000.01
+	err = transaction.Validate()
+	assert.ErrorIs(t, err, ErrAmountAboveMaximum)
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, 5000.0, limitErr.Limit)
+
+	transaction.Amount = 5000
+	assert.Nil(t, transaction.Validate())
+}
+
+func TestTransferPolicy_CurrencyPairs(t *testing.T) {
+	policy := &TransferPolicy{AllowedCurrencyPairs: []CurrencyPair{{From: "USD", To: "BRL"}}}
+	brl, usd := newFundedAccount("BRL"), newFundedAccount("USD")
+
+	assert.Nil(t, (&Transaction{AccountFrom: usd, AccountTo: brl, Amount: 10, Policy: policy}).Validate())
+	assert.Nil(t, (&Transaction{AccountFrom: brl, AccountTo: newFundedAccount("BRL"), Amount: 10, Policy: policy}).Validate())
+
+	err := (&Transaction{AccountFrom: brl, AccountTo: usd, Amount: 10, Policy: policy}).Validate()
+	var pairErr *CurrencyPairError
+	assert.ErrorIs(t, err, ErrCurrencyPairNotAllowed)
+	assert.True(t, errors.As(err, &pairErr))
+	assert.Equal(t, "BRL", pairErr.From)
+	assert.Equal(t, "USD", pairErr.To)
+
+	// Without allowed pairs, only same-currency transfers are allowed.
+	_, err = NewTransaction(usd, brl, 10)
+	assert.ErrorIs(t, err, ErrCurrencyPairNotAllowed)
+	assert.Equal(t, 10000.0, usd.Balance)
+	assert.Equal(t, 10000.0, brl.Balance)
+	_, err = NewTransaction(brl, newFundedAccount("BRL"), 10)
+	assert.Nil(t, err)
+}
+
+func TestTransferPolicy_CustomRules(t *testing.T) {
+	errBlocked := errors.New("blocked")
+	policy := &TransferPolicy{Rules: []TransferRule{
+		func(t *Transaction) error {
+			if t.Amount == 13 {
+				return errBlocked
+			}
+			return nil
+		},
+	}}
+	from, to := newFundedAccount("BRL"), newFundedAccount("BRL")
+
+	assert.ErrorIs(t, (&Transaction{AccountFrom: from, AccountTo: to, Amount: 13, Policy: policy}).Validate(), errBlocked)
+	assert.Nil(t, (&Transaction{AccountFrom: from, AccountTo: to, Amount: 12, Policy: policy}).Validate())
+}
+
+func TestDefaultTransferPolicy(t *testing.T) {
+	defaultPolicy := DefaultTransferPolicy
+	defer func() { DefaultTransferPolicy = defaultPolicy }()
+	DefaultTransferPolicy = TransferPolicy{MaxAmount: 50}
+
+	_, err := NewTransaction(newFundedAccount("BRL"), newFundedAccount("BRL"), 100)
+	assert.ErrorIs(t, err, ErrAmountAboveMaximum)
+}
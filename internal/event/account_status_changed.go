@@ -0,0 +1,30 @@
+package event
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const (
+	AccountStatusChangedName    = "AccountStatusChanged"
+	AccountStatusChangedVersion = 1
+)
+
+// AccountStatusChangedPayload describes an account being frozen, unfrozen or
+// closed, for Reason, one of the reason codes of entity.
+type AccountStatusChangedPayload struct {
+	AccountID      string `json:"account_id"`
+	ClientID       string `json:"client_id"`
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status"`
+	// FreezeMode is set when the account is frozen.
+	FreezeMode string `json:"freeze_mode,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+type AccountStatusChanged = events.Event[AccountStatusChangedPayload]
+
+func NewAccountStatusChangedEvent(ctx context.Context, payload AccountStatusChangedPayload) *AccountStatusChanged {
+	return events.NewEvent(ctx, AccountStatusChangedName, AccountStatusChangedVersion, "Account", payload.AccountID, payload)
+}
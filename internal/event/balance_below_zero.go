@@ -0,0 +1,30 @@
+package event
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const (
+	BalanceBelowZeroName    = "BalanceBelowZero"
+	BalanceBelowZeroVersion = 1
+)
+
+// BalanceBelowZeroPayload tells that TransactionID took the balance of an
+// account below zero, drawing on its overdraft limit or the credit line of
+// its client.
+type BalanceBelowZeroPayload struct {
+	AccountID     string  `json:"account_id"`
+	ClientID      string  `json:"client_id"`
+	TransactionID string  `json:"transaction_id"`
+	Balance       float64 `json:"balance"`
+	// Limit is how far below zero the account may go.
+	Limit float64 `json:"limit"`
+}
+
+type BalanceBelowZero = events.Event[BalanceBelowZeroPayload]
+
+func NewBalanceBelowZeroEvent(ctx context.Context, payload BalanceBelowZeroPayload) *BalanceBelowZero {
+	return events.NewEvent(ctx, BalanceBelowZeroName, BalanceBelowZeroVersion, "Account", payload.AccountID, payload)
+}
@@ -1,30 +1,30 @@
 package event
 
-import "time"
+import (
+	"context"
 
-type BalanceUpdated struct {
-	Name    string
-	Payload any
-}
+	"github.com/guimartiins/eda-go/pkg/events"
+)
 
-func NewBalanceUpdatedEvent() *BalanceUpdated {
-	return &BalanceUpdated{
-		Name: "BalanceUpdated",
-	}
-}
+const (
+	BalanceUpdatedName    = "BalanceUpdated"
+	BalanceUpdatedVersion = 1
+)
 
-func (e *BalanceUpdated) GetName() string {
-	return e.Name
+type BalanceUpdatedPayload struct {
+	AccountIDFrom        string  `json:"account_id_from"`
+	AccountIDTo          string  `json:"account_id_to"`
+	BalanceAccountIDFrom float64 `json:"balance_account_id_from"`
+	BalanceAccountIDTo   float64 `json:"balance_account_id_to"`
+	// VersionAccountIDFrom and VersionAccountIDTo are the balance versions
+	// of the accounts, which order the balances reported for each of them.
+	// They are zero in events published before accounts had one.
+	VersionAccountIDFrom int64 `json:"version_account_id_from,omitempty"`
+	VersionAccountIDTo   int64 `json:"version_account_id_to,omitempty"`
 }
 
-func (e *BalanceUpdated) GetPayload() any {
-	return e.Payload
-}
-
-func (e *BalanceUpdated) SetPayload(payload any) {
-	e.Payload = payload
-}
+type BalanceUpdated = events.Event[BalanceUpdatedPayload]
 
-func (e *BalanceUpdated) GetDateTime() time.Time {
-	return time.Now()
+func NewBalanceUpdatedEvent(ctx context.Context, transactionID string, payload BalanceUpdatedPayload) *BalanceUpdated {
+	return events.NewEvent(ctx, BalanceUpdatedName, BalanceUpdatedVersion, "Transaction", transactionID, payload)
 }
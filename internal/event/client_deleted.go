@@ -0,0 +1,24 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const (
+	ClientDeletedName    = "ClientDeleted"
+	ClientDeletedVersion = 1
+)
+
+type ClientDeletedPayload struct {
+	ClientID  string    `json:"client_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type ClientDeleted = events.Event[ClientDeletedPayload]
+
+func NewClientDeletedEvent(ctx context.Context, payload ClientDeletedPayload) *ClientDeleted {
+	return events.NewEvent(ctx, ClientDeletedName, ClientDeletedVersion, "Client", payload.ClientID, payload)
+}
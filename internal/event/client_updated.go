@@ -0,0 +1,28 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const (
+	ClientUpdatedName    = "ClientUpdated"
+	ClientUpdatedVersion = 1
+)
+
+// ClientUpdatedPayload carries the name and email of a client after an
+// update.
+type ClientUpdatedPayload struct {
+	ClientID  string    `json:"client_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ClientUpdated = events.Event[ClientUpdatedPayload]
+
+func NewClientUpdatedEvent(ctx context.Context, payload ClientUpdatedPayload) *ClientUpdated {
+	return events.NewEvent(ctx, ClientUpdatedName, ClientUpdatedVersion, "Client", payload.ClientID, payload)
+}
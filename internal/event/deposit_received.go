@@ -0,0 +1,29 @@
+package event
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const (
+	DepositReceivedName    = "DepositReceived"
+	DepositReceivedVersion = 1
+)
+
+type DepositReceivedPayload struct {
+	ID                  string  `json:"id"`
+	AccountID           string  `json:"account_id"`
+	SettlementAccountID string  `json:"settlement_account_id"`
+	Amount              float64 `json:"amount"`
+	// Balance and SettlementBalance are the balances of both accounts right
+	// after the deposit.
+	Balance           float64 `json:"balance"`
+	SettlementBalance float64 `json:"settlement_balance"`
+}
+
+type DepositReceived = events.Event[DepositReceivedPayload]
+
+func NewDepositReceivedEvent(ctx context.Context, payload DepositReceivedPayload) *DepositReceived {
+	return events.NewEvent(ctx, DepositReceivedName, DepositReceivedVersion, "Transaction", payload.ID, payload)
+}
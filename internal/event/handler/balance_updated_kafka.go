@@ -1,9 +1,10 @@
 package handler
 
 import (
-	"fmt"
+	"context"
 	"sync"
 
+	"github.com/guimartiins/eda-go/internal/event"
 	"github.com/guimartiins/eda-go/pkg/events"
 	"github.com/guimartiins/eda-go/pkg/kafka"
 )
@@ -21,6 +22,19 @@ func NewUpdateBalanceKafkaHandler(kafka *kafka.Producer) *UpdateBalanceKafkaHand
 func (h *UpdateBalanceKafkaHandler) Handle(message events.EventInterface, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	h.Kafka.Publish(message, nil, "balances")
-	fmt.Println("UpdateBalanceKafkaHandler called")
+	h.HandleContext(context.Background(), message)
+}
+
+func (h *UpdateBalanceKafkaHandler) HandleContext(ctx context.Context, message events.EventInterface) error {
+	return h.Kafka.Publish(message, balanceKey(message), "balances")
+}
+
+// balanceKey keys a BalanceUpdated message by the account the funds left, so
+// that the balances of an account stay in order within its partition. The
+// other account is ordered by its balance version.
+func balanceKey(message events.EventInterface) []byte {
+	if balanceUpdated, ok := message.(*event.BalanceUpdated); ok {
+		return []byte(balanceUpdated.Payload.AccountIDFrom)
+	}
+	return messageKey(message)
 }
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/kafka"
+)
+
+// KafkaTopicHandler publishes every event it receives to one topic, whatever
+// its name.
+type KafkaTopicHandler struct {
+	Kafka *kafka.Producer
+	Topic string
+}
+
+func NewKafkaTopicHandler(kafka *kafka.Producer, topic string) *KafkaTopicHandler {
+	return &KafkaTopicHandler{
+		Kafka: kafka,
+		Topic: topic,
+	}
+}
+
+func (h *KafkaTopicHandler) Handle(message events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	h.HandleContext(context.Background(), message)
+}
+
+func (h *KafkaTopicHandler) HandleContext(ctx context.Context, message events.EventInterface) error {
+	return h.Kafka.Publish(message, messageKey(message), h.Topic)
+}
@@ -0,0 +1,12 @@
+package handler
+
+import "github.com/guimartiins/eda-go/pkg/events"
+
+// messageKey keys Kafka messages by aggregate so that the events of one
+// aggregate land on the same partition, in order.
+func messageKey(message events.EventInterface) []byte {
+	if e, ok := message.(events.EnvelopedEvent); ok {
+		return []byte(e.GetEnvelope().AggregateID)
+	}
+	return nil
+}
@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"fmt"
+	"context"
 	"sync"
 
 	"github.com/guimartiins/eda-go/pkg/events"
@@ -21,6 +21,9 @@ func NewTransactionCreatedKafkaHandler(kafka *kafka.Producer) *TransactionCreate
 func (h *TransactionCreatedKafkaHandler) Handle(message events.EventInterface, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	h.Kafka.Publish(message, nil, "transactions")
-	fmt.Println("TransactionCreatedKafkaHandler: ", message.GetPayload())
+	h.HandleContext(context.Background(), message)
+}
+
+func (h *TransactionCreatedKafkaHandler) HandleContext(ctx context.Context, message events.EventInterface) error {
+	return h.Kafka.Publish(message, messageKey(message), "transactions")
 }
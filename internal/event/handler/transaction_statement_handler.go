@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+// TransactionStatementHandler projects the events moving money, which are
+// TransactionCreated, TransactionCaptured, DepositReceived,
+// WithdrawalCompleted and TransactionReversed, into the statements of both
+// accounts. Appending is idempotent, so the handler can be
+// retried and replayed.
+type TransactionStatementHandler struct {
+	Statements gateway.StatementGateway
+}
+
+func NewTransactionStatementHandler(statements gateway.StatementGateway) *TransactionStatementHandler {
+	return &TransactionStatementHandler{
+		Statements: statements,
+	}
+}
+
+func (h *TransactionStatementHandler) Handle(message events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	h.HandleContext(context.Background(), message)
+}
+
+func (h *TransactionStatementHandler) HandleContext(ctx context.Context, message events.EventInterface) error {
+	var lines []*entity.StatementLine
+	switch e := message.(type) {
+	case *event.TransactionCreated:
+		payload := e.Payload
+		lines = entity.NewStatementLines(payload.ID, payload.AccountIDFrom, payload.AccountIDTo, payload.Amount,
+			payload.BalanceAccountIDFrom, payload.BalanceAccountIDTo, e.OccurredAt)
+	case *event.DepositReceived:
+		payload := e.Payload
+		lines = entity.NewStatementLines(payload.ID, payload.SettlementAccountID, payload.AccountID, payload.Amount,
+			payload.SettlementBalance, payload.Balance, e.OccurredAt)
+	case *event.WithdrawalCompleted:
+		payload := e.Payload
+		lines = entity.NewStatementLines(payload.ID, payload.AccountID, payload.SettlementAccountID, payload.Amount,
+			payload.Balance, payload.SettlementBalance, e.OccurredAt)
+	case *event.TransactionCaptured:
+		payload := e.Payload
+		lines = entity.NewStatementLines(payload.ID, payload.AccountIDFrom, payload.AccountIDTo, payload.Amount,
+			payload.BalanceAccountIDFrom, payload.BalanceAccountIDTo, e.OccurredAt)
+	case *event.TransactionReversed:
+		payload := e.Payload
+		lines = entity.NewStatementLines(payload.ID, payload.AccountIDFrom, payload.AccountIDTo, payload.Amount,
+			payload.BalanceAccountIDFrom, payload.BalanceAccountIDTo, e.OccurredAt)
+	default:
+		return events.ErrUnexpectedPayload
+	}
+
+	for _, line := range lines {
+		if err := h.Statements.Append(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
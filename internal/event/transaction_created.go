@@ -1,29 +1,29 @@
 package event
 
-import "time"
+import (
+	"context"
 
-type TransactionCreated struct {
-	Name    string
-	Payload any
-}
+	"github.com/guimartiins/eda-go/pkg/events"
+)
 
-func NewTransactionCreatedEvent() *TransactionCreated {
-	return &TransactionCreated{
-		Name: "TransactionCreated",
-	}
-}
+const (
+	TransactionCreatedName    = "TransactionCreated"
+	TransactionCreatedVersion = 1
+)
 
-func (e *TransactionCreated) SetPayload(payload interface{}) {
-	e.Payload = payload
+type TransactionCreatedPayload struct {
+	ID            string  `json:"id"`
+	AccountIDFrom string  `json:"account_id_from"`
+	AccountIDTo   string  `json:"account_id_to"`
+	Amount        float64 `json:"amount"`
+	// BalanceAccountIDFrom and BalanceAccountIDTo are the balances of both
+	// accounts right after the transaction.
+	BalanceAccountIDFrom float64 `json:"balance_account_id_from"`
+	BalanceAccountIDTo   float64 `json:"balance_account_id_to"`
 }
 
-func (e *TransactionCreated) GetName() string {
-	return e.Name
-}
-func (e *TransactionCreated) GetPayload() interface{} {
-	return e.Payload
-}
+type TransactionCreated = events.Event[TransactionCreatedPayload]
 
-func (e *TransactionCreated) GetDateTime() time.Time {
-	return time.Now()
+func NewTransactionCreatedEvent(ctx context.Context, payload TransactionCreatedPayload) *TransactionCreated {
+	return events.NewEvent(ctx, TransactionCreatedName, TransactionCreatedVersion, "Transaction", payload.ID, payload)
 }
@@ -0,0 +1,69 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+// Events of the two-phase transfer lifecycle, one per status transition.
+const (
+	TransactionAuthorizedName = "TransactionAuthorized"
+	TransactionFailedName     = "TransactionFailed"
+	TransactionCapturedName   = "TransactionCaptured"
+	TransactionVoidedName     = "TransactionVoided"
+	TransactionExpiredName    = "TransactionExpired"
+
+	TransactionLifecycleVersion = 1
+)
+
+type TransactionLifecyclePayload struct {
+	ID            string  `json:"id"`
+	Status        string  `json:"status"`
+	AccountIDFrom string  `json:"account_id_from"`
+	AccountIDTo   string  `json:"account_id_to"`
+	Amount        float64 `json:"amount"`
+	// ExpiresAt is when the hold of an authorized transaction expires.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	// Reason is the error a failed authorization was refused with.
+	Reason string `json:"reason,omitempty"`
+	// BalanceAccountIDFrom and AvailableBalanceAccountIDFrom are the
+	// balance and available balance of the sender right after the
+	// transition; BalanceAccountIDTo the balance of the recipient.
+	BalanceAccountIDFrom          float64 `json:"balance_account_id_from"`
+	AvailableBalanceAccountIDFrom float64 `json:"available_balance_account_id_from"`
+	BalanceAccountIDTo            float64 `json:"balance_account_id_to"`
+}
+
+type (
+	TransactionAuthorized = events.Event[TransactionLifecyclePayload]
+	TransactionFailed     = events.Event[TransactionLifecyclePayload]
+	TransactionCaptured   = events.Event[TransactionLifecyclePayload]
+	TransactionVoided     = events.Event[TransactionLifecyclePayload]
+	TransactionExpired    = events.Event[TransactionLifecyclePayload]
+)
+
+func NewTransactionAuthorizedEvent(ctx context.Context, payload TransactionLifecyclePayload) *TransactionAuthorized {
+	return newTransactionLifecycleEvent(ctx, TransactionAuthorizedName, payload)
+}
+
+func NewTransactionFailedEvent(ctx context.Context, payload TransactionLifecyclePayload) *TransactionFailed {
+	return newTransactionLifecycleEvent(ctx, TransactionFailedName, payload)
+}
+
+func NewTransactionCapturedEvent(ctx context.Context, payload TransactionLifecyclePayload) *TransactionCaptured {
+	return newTransactionLifecycleEvent(ctx, TransactionCapturedName, payload)
+}
+
+func NewTransactionVoidedEvent(ctx context.Context, payload TransactionLifecyclePayload) *TransactionVoided {
+	return newTransactionLifecycleEvent(ctx, TransactionVoidedName, payload)
+}
+
+func NewTransactionExpiredEvent(ctx context.Context, payload TransactionLifecyclePayload) *TransactionExpired {
+	return newTransactionLifecycleEvent(ctx, TransactionExpiredName, payload)
+}
+
+func newTransactionLifecycleEvent(ctx context.Context, name string, payload TransactionLifecyclePayload) *events.Event[TransactionLifecyclePayload] {
+	return events.NewEvent(ctx, name, TransactionLifecycleVersion, "Transaction", payload.ID, payload)
+}
@@ -0,0 +1,40 @@
+package event
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const (
+	TransactionReversedName    = "TransactionReversed"
+	TransactionReversedVersion = 1
+)
+
+// TransactionReversedPayload describes a reversal, which moves Amount from
+// the recipient of the original transaction, AccountIDFrom, back to its
+// sender, AccountIDTo.
+type TransactionReversedPayload struct {
+	ID                    string  `json:"id"`
+	OriginalTransactionID string  `json:"original_transaction_id"`
+	AccountIDFrom         string  `json:"account_id_from"`
+	AccountIDTo           string  `json:"account_id_to"`
+	Amount                float64 `json:"amount"`
+	// BalanceAccountIDFrom and BalanceAccountIDTo are the balances of both
+	// accounts right after the reversal.
+	BalanceAccountIDFrom float64 `json:"balance_account_id_from"`
+	BalanceAccountIDTo   float64 `json:"balance_account_id_to"`
+	// OriginalStatus and OriginalReversedAmount describe the original
+	// transaction after the reversal. They are left empty by replays that
+	// do not cover the original transaction.
+	OriginalStatus         string  `json:"original_status,omitempty"`
+	OriginalReversedAmount float64 `json:"original_reversed_amount,omitempty"`
+}
+
+type TransactionReversed = events.Event[TransactionReversedPayload]
+
+// NewTransactionReversedEvent returns an event of the original transaction
+// aggregate, whose status the reversal changes.
+func NewTransactionReversedEvent(ctx context.Context, payload TransactionReversedPayload) *TransactionReversed {
+	return events.NewEvent(ctx, TransactionReversedName, TransactionReversedVersion, "Transaction", payload.OriginalTransactionID, payload)
+}
@@ -0,0 +1,29 @@
+package event
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const (
+	WithdrawalCompletedName    = "WithdrawalCompleted"
+	WithdrawalCompletedVersion = 1
+)
+
+type WithdrawalCompletedPayload struct {
+	ID                  string  `json:"id"`
+	AccountID           string  `json:"account_id"`
+	SettlementAccountID string  `json:"settlement_account_id"`
+	Amount              float64 `json:"amount"`
+	// Balance and SettlementBalance are the balances of both accounts right
+	// after the withdrawal.
+	Balance           float64 `json:"balance"`
+	SettlementBalance float64 `json:"settlement_balance"`
+}
+
+type WithdrawalCompleted = events.Event[WithdrawalCompletedPayload]
+
+func NewWithdrawalCompletedEvent(ctx context.Context, payload WithdrawalCompletedPayload) *WithdrawalCompleted {
+	return events.NewEvent(ctx, WithdrawalCompletedName, WithdrawalCompletedVersion, "Transaction", payload.ID, payload)
+}
@@ -5,5 +5,19 @@ import "github.com/guimartiins/eda-go/internal/entity"
 type AccountGateway interface {
 	Save(account *entity.Account) error
 	FindByID(id string) (*entity.Account, error)
+	FindByClientID(clientID string) ([]*entity.Account, error)
+	// Lock locks the accounts and their clients until the end of the
+	// transaction, in an order that cannot deadlock, so that the balances
+	// read afterwards are not overwritten by a concurrent movement and what
+	// is checked against the transactions they sent and the credit lines of
+	// their clients still holds at commit. A use case locks all the accounts
+	// it moves money between with a single call, before reading them.
+	Lock(ids ...string) error
 	UpdateBalance(account *entity.Account) error
+	UpdateSpendingLimits(account *entity.Account) error
+	// UpdateStatus saves the status of account. It returns
+	// ErrVersionConflict when the stored one is no longer previousStatus,
+	// and entity.ErrAccountNotEmpty when closing an account whose stored
+	// balance or held funds are no longer zero.
+	UpdateStatus(account *entity.Account, previousStatus string) error
 }
@@ -2,7 +2,23 @@ package gateway
 
 import "github.com/guimartiins/eda-go/internal/entity"
 
+// ClientFilter selects clients. Clients are returned newest first; Query,
+// when set, matches part of their name or email, and After skips the clients
+// up to and including the given one.
+type ClientFilter struct {
+	Query string
+	After *entity.Client
+	Limit int
+}
+
+// ClientGateway never returns deleted clients: Get, Update and Delete fail
+// with sql.ErrNoRows for them as for unknown ones.
 type ClientGateway interface {
 	Get(id string) (*entity.Client, error)
 	Save(client *entity.Client) error
+	// Update saves the name, email and update time of client.
+	Update(client *entity.Client) error
+	// Delete saves the deletion time of client.
+	Delete(client *entity.Client) error
+	List(filter ClientFilter) ([]*entity.Client, error)
 }
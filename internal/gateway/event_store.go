@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrVersionConflict = errors.New("aggregate was modified concurrently")
+
+type StoredEvent struct {
+	ID            string
+	AggregateID   string
+	AggregateType string
+	Version       int
+	Name          string
+	Payload       []byte
+	OccurredAt    time.Time
+}
+
+type Snapshot struct {
+	AggregateID   string
+	AggregateType string
+	Version       int
+	State         []byte
+	CreatedAt     time.Time
+}
+
+type EventStoreGateway interface {
+	// Append adds events to the stream of aggregateID, failing with
+	// ErrVersionConflict unless the stream is at expectedVersion.
+	Append(aggregateID string, expectedVersion int, events []StoredEvent) error
+	// Load returns the events of aggregateID after afterVersion, in order.
+	Load(aggregateID string, afterVersion int) ([]StoredEvent, error)
+	SaveSnapshot(snapshot Snapshot) error
+	// LoadSnapshot returns the latest snapshot of aggregateID, or nil when
+	// there is none.
+	LoadSnapshot(aggregateID string) (*Snapshot, error)
+}
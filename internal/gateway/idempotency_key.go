@@ -0,0 +1,22 @@
+package gateway
+
+import (
+	"errors"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+)
+
+// ErrIdempotencyKeyExists is returned when saving a key that a concurrent
+// request stored first.
+var ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+
+type IdempotencyKeyGateway interface {
+	// Find returns sql.ErrNoRows when the key is unknown.
+	Find(key string) (*entity.IdempotencyKey, error)
+	// Save returns ErrIdempotencyKeyExists when the key is already stored.
+	Save(key *entity.IdempotencyKey) error
+	Delete(key string) error
+	// DeleteExpired removes the keys expired at now and returns how many.
+	DeleteExpired(now time.Time) (int64, error)
+}
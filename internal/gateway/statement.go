@@ -0,0 +1,25 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+)
+
+// StatementFilter selects the lines of an account statement. Lines are
+// returned newest first; After, when set, skips the lines up to and including
+// the given one, and From and To bound their creation time.
+type StatementFilter struct {
+	From  time.Time
+	To    time.Time
+	After *entity.StatementLine
+	Limit int
+}
+
+type StatementGateway interface {
+	// Append stores a line unless the account already has one for the
+	// same transaction.
+	Append(line *entity.StatementLine) error
+	FindByAccountID(accountID string, filter StatementFilter) ([]*entity.StatementLine, error)
+	FindByID(id string) (*entity.StatementLine, error)
+}
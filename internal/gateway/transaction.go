@@ -1,7 +1,23 @@
 package gateway
 
-import "github.com/guimartiins/eda-go/internal/entity"
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+)
 
 type TransactionGateway interface {
 	Create(transaction *entity.Transaction) error
+	// UpdateStatus saves the status and reversed amount of transaction. It
+	// returns ErrVersionConflict when the stored ones are no longer
+	// previousStatus and previousReversedAmount.
+	UpdateStatus(transaction *entity.Transaction, previousStatus string, previousReversedAmount float64) error
+	// FindExpired returns up to limit authorized transactions whose hold
+	// expired before now.
+	FindExpired(now time.Time, limit int) ([]*entity.Transaction, error)
+	// OutgoingUsage sums the transfers and withdrawals sent by accountID since
+	// since, held or settled, including those reversed afterwards.
+	OutgoingUsage(accountID string, since time.Time) (entity.SpendingUsage, error)
+	FindByID(id string) (*entity.Transaction, error)
+	FindSince(since time.Time) ([]*entity.Transaction, error)
 }
@@ -0,0 +1,170 @@
+package authorize_transaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/internal/usecase/spending_limits"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+// DefaultHoldTTL is how long an authorization holds the funds of the sender
+// before it expires.
+const DefaultHoldTTL = 7 * 24 * time.Hour
+
+type AuthorizeTransactionInputDTO struct {
+	AccountIDFrom string  `json:"account_id_from"`
+	AccountIDTo   string  `json:"account_id_to"`
+	Amount        float64 `json:"amount"`
+}
+
+func (input AuthorizeTransactionInputDTO) Validate() error {
+	var errs validation.Errors
+	from := errs.Required("account_id_from", input.AccountIDFrom)
+	to := errs.Required("account_id_to", input.AccountIDTo)
+	if from && to && input.AccountIDFrom == input.AccountIDTo {
+		errs.Add("account_id_to", "same_account", "must differ from account_id_from")
+	}
+	errs.Positive("amount", input.Amount)
+	return errs.Err()
+}
+
+type AuthorizeTransactionOutputDTO struct {
+	ID            string    `json:"id"`
+	Status        string    `json:"status"`
+	AccountIDFrom string    `json:"account_id_from"`
+	AccountIDTo   string    `json:"account_id_to"`
+	Amount        float64   `json:"amount"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// AuthorizeTransactionUseCase starts a two-phase transfer: the amount is held
+// on the sender until the transaction is captured, voided or expires. Refused
+// authorizations are stored as failed before their error is returned.
+type AuthorizeTransactionUseCase struct {
+	Uow             uow.UowInterface
+	EventDispatcher events.EventDispatcherInterface
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+	HoldTTL      time.Duration
+}
+
+func NewAuthorizeTransactionUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface) *AuthorizeTransactionUseCase {
+	return &AuthorizeTransactionUseCase{
+		Uow:             Uow,
+		EventDispatcher: eventDispatcher,
+		HoldTTL:         DefaultHoldTTL,
+	}
+}
+
+func (uc *AuthorizeTransactionUseCase) Execute(ctx context.Context, input AuthorizeTransactionInputDTO) (*AuthorizeTransactionOutputDTO, error) {
+	var transaction *entity.Transaction
+	var authorizeErr error
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
+
+		err := accountRepository.Lock(input.AccountIDFrom, input.AccountIDTo)
+		if err != nil {
+			return err
+		}
+
+		accountFrom, err := accountRepository.FindByID(input.AccountIDFrom)
+		if err != nil {
+			return err
+		}
+
+		accountTo, err := accountRepository.FindByID(input.AccountIDTo)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		transaction = entity.NewPendingTransaction(accountFrom, accountTo, input.Amount)
+		authorizeErr = spending_limits.Check(transactionRepository, accountFrom, input.Amount, now)
+		if authorizeErr != nil {
+			if err := transaction.Fail(); err != nil {
+				return err
+			}
+		} else {
+			authorizeErr = transaction.Authorize(now.Add(uc.HoldTTL))
+		}
+		if authorizeErr == nil {
+			err = accountRepository.UpdateBalance(accountFrom)
+			if err != nil {
+				return err
+			}
+		}
+
+		return transactionRepository.Create(transaction)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload := newLifecyclePayload(transaction)
+	if authorizeErr != nil {
+		payload.Reason = authorizeErr.Error()
+		events.DispatchOrLog(uc.EventDispatcher, event.NewTransactionFailedEvent(ctx, payload))
+		return nil, authorizeErr
+	}
+	events.DispatchOrLog(uc.EventDispatcher, event.NewTransactionAuthorizedEvent(ctx, payload))
+
+	return &AuthorizeTransactionOutputDTO{
+		ID:            transaction.ID,
+		Status:        transaction.Status,
+		AccountIDFrom: transaction.AccountFrom.ID,
+		AccountIDTo:   transaction.AccountTo.ID,
+		Amount:        transaction.Amount,
+		ExpiresAt:     transaction.ExpiresAt,
+	}, nil
+}
+
+func newLifecyclePayload(transaction *entity.Transaction) event.TransactionLifecyclePayload {
+	return event.TransactionLifecyclePayload{
+		ID:                            transaction.ID,
+		Status:                        transaction.Status,
+		AccountIDFrom:                 transaction.AccountFrom.ID,
+		AccountIDTo:                   transaction.AccountTo.ID,
+		Amount:                        transaction.Amount,
+		ExpiresAt:                     transaction.ExpiresAt,
+		BalanceAccountIDFrom:          transaction.AccountFrom.Balance,
+		AvailableBalanceAccountIDFrom: transaction.AccountFrom.AvailableBalance(),
+		BalanceAccountIDTo:            transaction.AccountTo.Balance,
+	}
+}
+
+func (uc *AuthorizeTransactionUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
+
+func (uc *AuthorizeTransactionUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
+	if err != nil {
+		panic(err)
+	}
+	transactionRepo, ok := repo.(gateway.TransactionGateway)
+	if !ok {
+		panic("repository is not of type TransactionGateway")
+	}
+	return transactionRepo
+}
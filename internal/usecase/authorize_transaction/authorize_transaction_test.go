@@ -0,0 +1,147 @@
+package authorize_transaction
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type AuthorizeTransactionUseCaseTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	db           *sql.DB
+	accounts     *database.AccountDB
+	transactions *database.TransactionDB
+	recorder     *EventRecorder
+	useCase      *AuthorizeTransactionUseCase
+	account1     *entity.Account
+	account2     *entity.Account
+}
+
+func (s *AuthorizeTransactionUseCaseTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+
+	client, _ := entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	s.account1 = entity.NewAccount(client)
+	s.account1.Credit(100)
+	s.account2 = entity.NewAccount(client)
+	s.accounts = database.NewAccountDB(db)
+	s.transactions = database.NewTransactionDB(db)
+	s.Nil(s.accounts.Save(s.account1))
+	s.Nil(s.accounts.Save(s.account2))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.TransactionAuthorizedName, s.recorder)
+	dispatcher.Register(event.TransactionFailedName, s.recorder)
+	s.useCase = NewAuthorizeTransactionUseCase(u, dispatcher)
+}
+
+func (s *AuthorizeTransactionUseCaseTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *AuthorizeTransactionUseCaseTestSuite) TestExecute() {
+	s.useCase.HoldTTL = time.Hour
+	output, err := s.useCase.Execute(s.ctx, AuthorizeTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 60})
+	s.Nil(err)
+	s.Equal(entity.TransactionAuthorized, output.Status)
+	s.WithinDuration(time.Now().Add(time.Hour), output.ExpiresAt, time.Minute)
+
+	account, err := s.accounts.FindByID(s.account1.ID)
+	s.Nil(err)
+	s.Equal(100.0, account.Balance)
+	s.Equal(40.0, account.AvailableBalance())
+	transaction, err := s.transactions.FindByID(output.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionAuthorized, transaction.Status)
+
+	s.Len(s.recorder.events, 1)
+	authorized := s.recorder.events[0].(*event.TransactionAuthorized)
+	s.Equal(event.TransactionAuthorizedName, authorized.GetName())
+	s.Equal(40.0, authorized.Payload.AvailableBalanceAccountIDFrom)
+}
+
+func (s *AuthorizeTransactionUseCaseTestSuite) TestExecute_StoresFailedAuthorization() {
+	_, err := s.useCase.Execute(s.ctx, AuthorizeTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 60})
+	s.Nil(err)
+
+	output, err := s.useCase.Execute(s.ctx, AuthorizeTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 60})
+	s.ErrorIs(err, entity.ErrInsufficientFunds)
+	s.Nil(output)
+
+	s.Len(s.recorder.events, 2)
+	failed := s.recorder.events[1].(*event.TransactionFailed)
+	s.Equal(event.TransactionFailedName, failed.GetName())
+	s.Equal(entity.TransactionFailed, failed.Payload.Status)
+	s.Equal(entity.ErrInsufficientFunds.Error(), failed.Payload.Reason)
+
+	transaction, err := s.transactions.FindByID(failed.Payload.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionFailed, transaction.Status)
+	account, err := s.accounts.FindByID(s.account1.ID)
+	s.Nil(err)
+	s.Equal(60.0, account.Held)
+}
+
+func (s *AuthorizeTransactionUseCaseTestSuite) TestExecute_SpendingLimitExceeded() {
+	s.account1.SpendingLimits = entity.SpendingLimits{DailyAmount: 50}
+	s.Nil(s.accounts.UpdateSpendingLimits(s.account1))
+	_, err := s.useCase.Execute(s.ctx, AuthorizeTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 30})
+	s.Nil(err)
+
+	output, err := s.useCase.Execute(s.ctx, AuthorizeTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 30})
+	var limitErr *entity.LimitExceededError
+	s.ErrorAs(err, &limitErr)
+	s.Equal(entity.LimitDailyAmount, limitErr.Limit)
+	s.Equal(20.0, limitErr.Remaining)
+	s.Nil(output)
+
+	s.Len(s.recorder.events, 2)
+	failed := s.recorder.events[1].(*event.TransactionFailed)
+	s.Equal(entity.TransactionFailed, failed.Payload.Status)
+	transaction, err := s.transactions.FindByID(failed.Payload.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionFailed, transaction.Status)
+	account, err := s.accounts.FindByID(s.account1.ID)
+	s.Nil(err)
+	s.Equal(30.0, account.Held)
+}
+
+func TestAuthorizeTransactionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizeTransactionUseCaseTestSuite))
+}
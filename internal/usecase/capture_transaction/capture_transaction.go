@@ -0,0 +1,159 @@
+package capture_transaction
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+)
+
+type CaptureTransactionInputDTO struct {
+	ID string
+}
+
+type CaptureTransactionOutputDTO struct {
+	ID            string  `json:"id"`
+	Status        string  `json:"status"`
+	AccountIDFrom string  `json:"account_id_from"`
+	AccountIDTo   string  `json:"account_id_to"`
+	Amount        float64 `json:"amount"`
+}
+
+// CaptureTransactionUseCase settles an authorized transaction, moving the
+// held amount from the sender to the recipient.
+type CaptureTransactionUseCase struct {
+	Uow             uow.UowInterface
+	EventDispatcher events.EventDispatcherInterface
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+}
+
+func NewCaptureTransactionUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface) *CaptureTransactionUseCase {
+	return &CaptureTransactionUseCase{
+		Uow:             Uow,
+		EventDispatcher: eventDispatcher,
+	}
+}
+
+func (uc *CaptureTransactionUseCase) Execute(ctx context.Context, input CaptureTransactionInputDTO) (*CaptureTransactionOutputDTO, error) {
+	var transaction *entity.Transaction
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
+
+		var err error
+		transaction, err = transactionRepository.FindByID(input.ID)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.Lock(transaction.AccountFrom.ID, transaction.AccountTo.ID)
+		if err != nil {
+			return err
+		}
+
+		// The stored transaction only carries the IDs of its accounts.
+		transaction.AccountFrom, err = accountRepository.FindByID(transaction.AccountFrom.ID)
+		if err != nil {
+			return err
+		}
+		transaction.AccountTo, err = accountRepository.FindByID(transaction.AccountTo.ID)
+		if err != nil {
+			return err
+		}
+
+		previousStatus := transaction.Status
+		err = transaction.Capture()
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(transaction.AccountFrom)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(transaction.AccountTo)
+		if err != nil {
+			return err
+		}
+
+		return transactionRepository.UpdateStatus(transaction, previousStatus, transaction.ReversedAmount)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactionCaptured := event.NewTransactionCapturedEvent(ctx, event.TransactionLifecyclePayload{
+		ID:                            transaction.ID,
+		Status:                        transaction.Status,
+		AccountIDFrom:                 transaction.AccountFrom.ID,
+		AccountIDTo:                   transaction.AccountTo.ID,
+		Amount:                        transaction.Amount,
+		BalanceAccountIDFrom:          transaction.AccountFrom.Balance,
+		AvailableBalanceAccountIDFrom: transaction.AccountFrom.AvailableBalance(),
+		BalanceAccountIDTo:            transaction.AccountTo.Balance,
+	})
+	events.DispatchOrLog(uc.EventDispatcher, transactionCaptured)
+
+	ctx = events.WithCausationID(ctx, transactionCaptured.ID)
+	events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceUpdatedEvent(ctx, transaction.ID, event.BalanceUpdatedPayload{
+		AccountIDFrom:        transaction.AccountFrom.ID,
+		AccountIDTo:          transaction.AccountTo.ID,
+		BalanceAccountIDFrom: transaction.AccountFrom.Balance,
+		BalanceAccountIDTo:   transaction.AccountTo.Balance,
+		VersionAccountIDFrom: transaction.AccountFrom.BalanceVersion,
+		VersionAccountIDTo:   transaction.AccountTo.BalanceVersion,
+	}))
+
+	if transaction.OverdrewAccountFrom() {
+		events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceBelowZeroEvent(ctx, event.BalanceBelowZeroPayload{
+			AccountID:     transaction.AccountFrom.ID,
+			ClientID:      transaction.AccountFrom.Client.ID,
+			TransactionID: transaction.ID,
+			Balance:       transaction.AccountFrom.Balance,
+			Limit:         transaction.AccountFrom.Limit(),
+		}))
+	}
+
+	return &CaptureTransactionOutputDTO{
+		ID:            transaction.ID,
+		Status:        transaction.Status,
+		AccountIDFrom: transaction.AccountFrom.ID,
+		AccountIDTo:   transaction.AccountTo.ID,
+		Amount:        transaction.Amount,
+	}, nil
+}
+
+func (uc *CaptureTransactionUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
+
+func (uc *CaptureTransactionUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
+	if err != nil {
+		panic(err)
+	}
+	transactionRepo, ok := repo.(gateway.TransactionGateway)
+	if !ok {
+		panic("repository is not of type TransactionGateway")
+	}
+	return transactionRepo
+}
@@ -0,0 +1,77 @@
+package close_account
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+// CloseAccountInputDTO closes an account for good. Its balance must be zero,
+// with no funds held.
+type CloseAccountInputDTO struct {
+	AccountID string `json:"-"`
+	Reason    string `json:"reason"`
+}
+
+func (input CloseAccountInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("account_id", input.AccountID)
+	if errs.Required("reason", input.Reason) {
+		errs.OneOf("reason", input.Reason, entity.StatusReasons)
+	}
+	return errs.Err()
+}
+
+type CloseAccountOutputDTO struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type CloseAccountUseCase struct {
+	AccountGateway  gateway.AccountGateway
+	EventDispatcher events.EventDispatcherInterface
+}
+
+func NewCloseAccountUseCase(accountGateway gateway.AccountGateway, eventDispatcher events.EventDispatcherInterface) *CloseAccountUseCase {
+	return &CloseAccountUseCase{
+		AccountGateway:  accountGateway,
+		EventDispatcher: eventDispatcher,
+	}
+}
+
+func (uc *CloseAccountUseCase) Execute(ctx context.Context, input CloseAccountInputDTO) (*CloseAccountOutputDTO, error) {
+	account, err := uc.AccountGateway.FindByID(input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStatus := account.Status
+	err = account.Close(input.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.AccountGateway.UpdateStatus(account, previousStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	events.DispatchOrLog(uc.EventDispatcher, event.NewAccountStatusChangedEvent(ctx, event.AccountStatusChangedPayload{
+		AccountID:      account.ID,
+		ClientID:       account.Client.ID,
+		Status:         account.Status,
+		PreviousStatus: previousStatus,
+		Reason:         account.StatusReason,
+	}))
+
+	return &CloseAccountOutputDTO{
+		ID:     account.ID,
+		Status: account.Status,
+		Reason: account.StatusReason,
+	}, nil
+}
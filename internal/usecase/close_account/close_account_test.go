@@ -0,0 +1,123 @@
+package close_account
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	account, _ := args.Get(0).(*entity.Account)
+	return account, args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+func newRecordingDispatcher() (*events.EventDispatcher, *EventRecorder) {
+	recorder := &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.AccountStatusChangedName, recorder)
+	return dispatcher, recorder
+}
+
+func TestCloseAccountUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	m.On("UpdateStatus", account, entity.AccountActive).Return(nil)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewCloseAccountUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonCustomerRequest})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.AccountClosed, output.Status)
+	m.AssertExpectations(t)
+	assert.Len(t, recorder.events, 1)
+	assert.Equal(t, entity.AccountClosed, recorder.events[0].(*event.AccountStatusChanged).Payload.Status)
+}
+
+func TestCloseAccountUseCase_Execute_NotEmpty(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	account.Credit(10)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewCloseAccountUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonCustomerRequest})
+
+	assert.ErrorIs(t, err, entity.ErrAccountNotEmpty)
+	assert.Nil(t, output)
+	assert.Empty(t, recorder.events)
+	m.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestCloseAccountUseCase_Execute_FundedMeanwhile(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	m.On("UpdateStatus", account, entity.AccountActive).Return(entity.ErrAccountNotEmpty)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewCloseAccountUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonCustomerRequest})
+
+	assert.ErrorIs(t, err, entity.ErrAccountNotEmpty)
+	assert.Nil(t, output)
+	assert.Empty(t, recorder.events)
+}
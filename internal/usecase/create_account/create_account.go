@@ -3,10 +3,25 @@ package create_account
 import (
 	"github.com/guimartiins/eda-go/internal/entity"
 	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/validation"
 )
 
 type CreateAccountInputDTO struct {
 	ClientID string `json:"client_id"`
+	// Currency is an ISO 4217 code, entity.DefaultCurrency when empty.
+	Currency string `json:"currency"`
+	// OverdraftLimit is how far below zero the balance may go.
+	OverdraftLimit float64 `json:"overdraft_limit"`
+}
+
+func (input CreateAccountInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("client_id", input.ClientID)
+	if input.Currency != "" {
+		errs.Currency("currency", input.Currency)
+	}
+	errs.NonNegative("overdraft_limit", input.OverdraftLimit)
+	return errs.Err()
 }
 
 type CreateAccountOutputDTO struct {
@@ -31,7 +46,12 @@ func (u *CreateAccountUseCase) Execute(input CreateAccountInputDTO) (*CreateAcco
 		return nil, err
 	}
 
-	account := entity.NewAccount(client)
+	currency := input.Currency
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+	account := entity.NewAccountInCurrency(client, currency)
+	account.OverdraftLimit = input.OverdraftLimit
 
 	err = u.AccountGateway.Save(account)
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -26,6 +28,21 @@ func (m *ClientGatewayMock) Save(client *entity.Client) error {
 	return args.Error(0)
 }
 
+func (m *ClientGatewayMock) Update(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Delete(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*entity.Client), args.Error(1)
+}
+
 func (m *AccountGatewayMock) Save(account *entity.Account) error {
 	args := m.Called(account)
 	return args.Error(0)
@@ -36,11 +53,31 @@ func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
 	return args.Get(0).(*entity.Account), args.Error(1)
 }
 
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
 func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
 	args := m.Called(account)
 	return args.Error(0)
 }
 
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
 // -------------------------------------------------------------- //
 func TestCreateAccountUseCase_Execute(t *testing.T) {
 	client, _ := entity.NewClient("John Doe", "j@j.com")
@@ -62,3 +99,26 @@ func TestCreateAccountUseCase_Execute(t *testing.T) {
 	cm.AssertNumberOfCalls(t, "Get", 1)
 	am.AssertNumberOfCalls(t, "Save", 1)
 }
+
+func TestCreateAccountUseCase_Execute_WithCurrency(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	cm := &ClientGatewayMock{}
+	am := &AccountGatewayMock{}
+	cm.On("Get", client.ID).Return(client, nil)
+	am.On("Save", mock.MatchedBy(func(account *entity.Account) bool {
+		return account.Currency == "USD"
+	})).Return(nil)
+
+	uc := NewCreateAccountUseCase(am, cm)
+	_, err := uc.Execute(CreateAccountInputDTO{ClientID: client.ID, Currency: "USD"})
+
+	assert.Nil(t, err)
+	am.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestCreateAccountInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, CreateAccountInputDTO{ClientID: "client"}.Validate())
+	assert.Nil(t, CreateAccountInputDTO{ClientID: "client", Currency: "USD"}.Validate())
+	assert.ErrorIs(t, CreateAccountInputDTO{ClientID: " "}.Validate(), validation.ErrInvalid)
+	assert.ErrorIs(t, CreateAccountInputDTO{ClientID: "client", Currency: "usd"}.Validate(), validation.ErrInvalid)
+}
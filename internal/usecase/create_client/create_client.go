@@ -5,19 +5,41 @@ import (
 
 	"github.com/guimartiins/eda-go/internal/entity"
 	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/validation"
 )
 
 type CreateClientInputDTO struct {
-	Name  string
-	Email string
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// CreditLine is how far below zero the accounts of the client may go
+	// together, past their own overdraft limits.
+	CreditLine float64 `json:"credit_line"`
+	// SpendingLimits are the defaults of the accounts of the client.
+	SpendingLimits entity.SpendingLimits `json:"spending_limits"`
+}
+
+func (input CreateClientInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("name", input.Name)
+	if errs.Required("email", input.Email) {
+		errs.Email("email", input.Email)
+	}
+	errs.NonNegative("credit_line", input.CreditLine)
+	errs.NonNegative("spending_limits.daily_amount", input.SpendingLimits.DailyAmount)
+	errs.NonNegative("spending_limits.daily_count", float64(input.SpendingLimits.DailyCount))
+	errs.NonNegative("spending_limits.monthly_amount", input.SpendingLimits.MonthlyAmount)
+	errs.NonNegative("spending_limits.monthly_count", float64(input.SpendingLimits.MonthlyCount))
+	return errs.Err()
 }
 
 type CreateClientOutputDTO struct {
-	ID        string
-	Name      string
-	Email     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             string
+	Name           string
+	Email          string
+	CreditLine     float64
+	SpendingLimits entity.SpendingLimits
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type CreateClientUseCase struct {
@@ -34,6 +56,8 @@ func (u *CreateClientUseCase) Execute(input CreateClientInputDTO) (*CreateClient
 	if err != nil {
 		return nil, err
 	}
+	client.CreditLine = input.CreditLine
+	client.SpendingLimits = input.SpendingLimits
 
 	err = u.ClientGateway.Save(client)
 	if err != nil {
@@ -42,10 +66,12 @@ func (u *CreateClientUseCase) Execute(input CreateClientInputDTO) (*CreateClient
 	}
 
 	return &CreateClientOutputDTO{
-		ID:        client.ID,
-		Name:      client.Name,
-		Email:     client.Email,
-		CreatedAt: client.CreatedAt,
-		UpdatedAt: client.UpdatedAt,
+		ID:             client.ID,
+		Name:           client.Name,
+		Email:          client.Email,
+		CreditLine:     client.CreditLine,
+		SpendingLimits: client.SpendingLimits,
+		CreatedAt:      client.CreatedAt,
+		UpdatedAt:      client.UpdatedAt,
 	}, nil
 }
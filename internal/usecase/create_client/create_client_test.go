@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -22,6 +24,21 @@ func (m *ClientGatewayMock) Save(client *entity.Client) error {
 	return args.Error(0)
 }
 
+func (m *ClientGatewayMock) Update(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Delete(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*entity.Client), args.Error(1)
+}
+
 func TestCreateClientUseCase_Execute(t *testing.T) {
 	m := &ClientGatewayMock{}
 	m.On("Save", mock.Anything).Return(nil)
@@ -40,3 +57,15 @@ func TestCreateClientUseCase_Execute(t *testing.T) {
 	m.AssertExpectations(t)
 	m.AssertNumberOfCalls(t, "Save", 1)
 }
+
+func TestCreateClientInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, CreateClientInputDTO{Name: "John Doe", Email: "j@j.com"}.Validate())
+
+	var errs validation.Errors
+	err := CreateClientInputDTO{Name: "", Email: "john"}.Validate()
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "name", errs[0].Field)
+	assert.Equal(t, "email", errs[1].Field)
+	assert.Equal(t, "invalid_email", errs[1].Code)
+}
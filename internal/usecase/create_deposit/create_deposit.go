@@ -0,0 +1,159 @@
+package create_deposit
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+type CreateDepositInputDTO struct {
+	AccountID string  `json:"-"`
+	Amount    float64 `json:"amount"`
+}
+
+func (input CreateDepositInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("account_id", input.AccountID)
+	errs.Positive("amount", input.Amount)
+	return errs.Err()
+}
+
+type CreateDepositOutputDTO struct {
+	ID        string  `json:"id"`
+	AccountID string  `json:"account_id"`
+	Amount    float64 `json:"amount"`
+	Balance   float64 `json:"balance"`
+}
+
+// CreateDepositUseCase funds an account with money entering the system,
+// which is taken from the settlement account.
+type CreateDepositUseCase struct {
+	Uow                 uow.UowInterface
+	EventDispatcher     events.EventDispatcherInterface
+	SettlementAccountID string
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+}
+
+func NewCreateDepositUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface, settlementAccountID string) *CreateDepositUseCase {
+	return &CreateDepositUseCase{
+		Uow:                 Uow,
+		EventDispatcher:     eventDispatcher,
+		SettlementAccountID: settlementAccountID,
+	}
+}
+
+func (uc *CreateDepositUseCase) Execute(ctx context.Context, input CreateDepositInputDTO) (*CreateDepositOutputDTO, error) {
+	output := &CreateDepositOutputDTO{}
+	payload := event.DepositReceivedPayload{}
+	balanceUpdated := event.BalanceUpdatedPayload{}
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
+
+		err := accountRepository.Lock(uc.SettlementAccountID, input.AccountID)
+		if err != nil {
+			return err
+		}
+
+		settlement, err := accountRepository.FindByID(uc.SettlementAccountID)
+		if err != nil {
+			return err
+		}
+
+		account, err := accountRepository.FindByID(input.AccountID)
+		if err != nil {
+			return err
+		}
+
+		deposit, err := entity.NewDeposit(settlement, account, input.Amount)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(settlement)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(account)
+		if err != nil {
+			return err
+		}
+
+		err = transactionRepository.Create(deposit)
+		if err != nil {
+			return err
+		}
+
+		output.ID = deposit.ID
+		output.AccountID = account.ID
+		output.Amount = deposit.Amount
+		output.Balance = account.Balance
+
+		payload = event.DepositReceivedPayload{
+			ID:                  deposit.ID,
+			AccountID:           account.ID,
+			SettlementAccountID: settlement.ID,
+			Amount:              deposit.Amount,
+			Balance:             account.Balance,
+			SettlementBalance:   settlement.Balance,
+		}
+		balanceUpdated = event.BalanceUpdatedPayload{
+			AccountIDFrom:        settlement.ID,
+			AccountIDTo:          account.ID,
+			BalanceAccountIDFrom: settlement.Balance,
+			BalanceAccountIDTo:   account.Balance,
+			VersionAccountIDFrom: settlement.BalanceVersion,
+			VersionAccountIDTo:   account.BalanceVersion,
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	depositReceived := event.NewDepositReceivedEvent(ctx, payload)
+	events.DispatchOrLog(uc.EventDispatcher, depositReceived)
+
+	ctx = events.WithCausationID(ctx, depositReceived.ID)
+	events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceUpdatedEvent(ctx, output.ID, balanceUpdated))
+
+	return output, nil
+}
+
+func (uc *CreateDepositUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
+
+func (uc *CreateDepositUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
+	if err != nil {
+		panic(err)
+	}
+	transactionRepo, ok := repo.(gateway.TransactionGateway)
+	if !ok {
+		panic("repository is not of type TransactionGateway")
+	}
+	return transactionRepo
+}
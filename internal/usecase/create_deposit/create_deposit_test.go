@@ -0,0 +1,129 @@
+package create_deposit
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type CreateDepositUseCaseTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	db         *sql.DB
+	accounts   *database.AccountDB
+	recorder   *EventRecorder
+	useCase    *CreateDepositUseCase
+	settlement *entity.Account
+	account    *entity.Account
+}
+
+func (s *CreateDepositUseCaseTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+
+	client, _ := entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	s.settlement = entity.NewAccount(client)
+	s.account = entity.NewAccount(client)
+	s.accounts = database.NewAccountDB(db)
+	s.Nil(s.accounts.Save(s.settlement))
+	s.Nil(s.accounts.Save(s.account))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.DepositReceivedName, s.recorder)
+	dispatcher.Register(event.BalanceUpdatedName, s.recorder)
+	s.useCase = NewCreateDepositUseCase(u, dispatcher, s.settlement.ID)
+}
+
+func (s *CreateDepositUseCaseTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *CreateDepositUseCaseTestSuite) TestExecute() {
+	output, err := s.useCase.Execute(s.ctx, CreateDepositInputDTO{AccountID: s.account.ID, Amount: 100})
+	s.Nil(err)
+	s.NotEmpty(output.ID)
+	s.Equal(s.account.ID, output.AccountID)
+	s.Equal(100.0, output.Amount)
+	s.Equal(100.0, output.Balance)
+
+	account, err := s.accounts.FindByID(s.account.ID)
+	s.Nil(err)
+	s.Equal(100.0, account.Balance)
+	settlement, err := s.accounts.FindByID(s.settlement.ID)
+	s.Nil(err)
+	s.Equal(-100.0, settlement.Balance)
+
+	var transactionType string
+	s.Nil(s.db.QueryRow("SELECT type FROM transactions WHERE id = ?", output.ID).Scan(&transactionType))
+	s.Equal(entity.TransactionDeposit, transactionType)
+
+	s.Len(s.recorder.events, 2)
+	received := s.recorder.events[0].(*event.DepositReceived)
+	s.Equal(output.ID, received.Payload.ID)
+	s.Equal(s.settlement.ID, received.Payload.SettlementAccountID)
+	s.Equal(-100.0, received.Payload.SettlementBalance)
+	updated := s.recorder.events[1].(*event.BalanceUpdated)
+	s.Equal(received.ID, updated.CausationID)
+	s.Equal(s.account.ID, updated.Payload.AccountIDTo)
+	s.Equal(100.0, updated.Payload.BalanceAccountIDTo)
+}
+
+func (s *CreateDepositUseCaseTestSuite) TestExecute_AccountNotFound() {
+	output, err := s.useCase.Execute(s.ctx, CreateDepositInputDTO{AccountID: "invalid_id", Amount: 100})
+	s.ErrorIs(err, sql.ErrNoRows)
+	s.Nil(output)
+	s.Empty(s.recorder.events)
+
+	settlement, err := s.accounts.FindByID(s.settlement.ID)
+	s.Nil(err)
+	s.Equal(0.0, settlement.Balance)
+}
+
+func TestCreateDepositInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, CreateDepositInputDTO{AccountID: "a", Amount: 10}.Validate())
+
+	var errs validation.Errors
+	assert.ErrorAs(t, CreateDepositInputDTO{Amount: -1}.Validate(), &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "account_id", errs[0].Field)
+	assert.Equal(t, "amount", errs[1].Field)
+}
+
+func TestCreateDepositUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(CreateDepositUseCaseTestSuite))
+}
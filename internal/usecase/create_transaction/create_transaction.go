@@ -2,17 +2,44 @@ package create_transaction
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
 	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/internal/usecase/spending_limits"
 	"github.com/guimartiins/eda-go/pkg/events"
 	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
 )
 
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+
 type CreateTransactionInputDTO struct {
 	AccountIDFrom string  `json:"account_id_from"`
 	AccountIDTo   string  `json:"account_id_to"`
 	Amount        float64 `json:"amount"`
+	// IdempotencyKey, when set, makes retries of the same request return the
+	// original output instead of creating another transaction.
+	IdempotencyKey string `json:"-"`
+}
+
+func (input CreateTransactionInputDTO) Validate() error {
+	var errs validation.Errors
+	from := errs.Required("account_id_from", input.AccountIDFrom)
+	to := errs.Required("account_id_to", input.AccountIDTo)
+	if from && to && input.AccountIDFrom == input.AccountIDTo {
+		errs.Add("account_id_to", "same_account", "must differ from account_id_from")
+	}
+	errs.Positive("amount", input.Amount)
+	return errs.Err()
 }
 
 type CreateTransactionOutputDTO struct {
@@ -20,37 +47,74 @@ type CreateTransactionOutputDTO struct {
 	AccountIDFrom string  `json:"account_id_from"`
 	AccountIDTo   string  `json:"account_id_to"`
 	Amount        float64 `json:"amount"`
-}
-
-type BalanceUpdatedOutputDTO struct {
-	AccountIDFrom        string  `json:"account_id_from"`
-	AccountIDTo          string  `json:"account_id_to"`
-	BalanceAccountIDFROM float64 `json:"balance_account_id_from"`
-	BalanceAccountIDTO   float64 `json:"balance_account_id_to"`
+	// Replayed tells that the output was stored for an earlier request with
+	// the same idempotency key.
+	Replayed bool `json:"-"`
 }
 
 type CreateTransactionUseCase struct {
-	Uow                uow.UowInterface
-	EventDispatcher    events.EventDispatcherInterface
-	transactionCreated events.EventInterface
-	balanceUpdated     events.EventInterface
+	Uow             uow.UowInterface
+	EventDispatcher events.EventDispatcherInterface
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+	// IdempotencyKeyTTL is how long an idempotency key, registered as
+	// "IdempotencyKeyDB", is honoured.
+	IdempotencyKeyTTL time.Duration
 }
 
-func NewCreateTransactionUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface, transactionCreated events.EventInterface, balanceUpdated events.EventInterface) *CreateTransactionUseCase {
+func NewCreateTransactionUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface) *CreateTransactionUseCase {
 	return &CreateTransactionUseCase{
-		Uow:                Uow,
-		EventDispatcher:    eventDispatcher,
-		transactionCreated: transactionCreated,
-		balanceUpdated:     balanceUpdated,
+		Uow:               Uow,
+		EventDispatcher:   eventDispatcher,
+		IdempotencyKeyTTL: DefaultIdempotencyKeyTTL,
 	}
 }
 
 func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTransactionInputDTO) (*CreateTransactionOutputDTO, error) {
+	output, err := uc.execute(ctx, input)
+	if errors.Is(err, gateway.ErrIdempotencyKeyExists) {
+		// A concurrent request with the same key committed first, rolling
+		// this transfer back. Running again replays its output, or rejects
+		// the key if that request was a different one.
+		output, err = uc.execute(ctx, input)
+	}
+	return output, err
+}
+
+func (uc *CreateTransactionUseCase) execute(ctx context.Context, input CreateTransactionInputDTO) (*CreateTransactionOutputDTO, error) {
 	output := &CreateTransactionOutputDTO{}
-	balanceUpdatedOutput := &BalanceUpdatedOutputDTO{}
-	err := uc.Uow.Do(ctx, func(_ *uow.Uow) error {
-		accountRepository := uc.getAccountRepository(ctx)
-		transactionRepository := uc.getTransactionRepository(ctx)
+	balanceUpdatedPayload := event.BalanceUpdatedPayload{}
+	var belowZero *event.BalanceBelowZeroPayload
+	requestHash := input.hash()
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		// Locking comes first, as the first plain read of a MySQL
+		// transaction takes the snapshot the later ones see.
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		err := accountRepository.Lock(input.AccountIDFrom, input.AccountIDTo)
+		if err != nil {
+			return err
+		}
+
+		if input.IdempotencyKey != "" {
+			stored, err := uc.getIdempotencyKeyRepository(ctx, tx).Find(input.IdempotencyKey)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+			case err != nil:
+				return err
+			case stored.Expired(time.Now()):
+				if err := uc.getIdempotencyKeyRepository(ctx, tx).Delete(input.IdempotencyKey); err != nil {
+					return err
+				}
+			case stored.RequestHash != requestHash:
+				return ErrIdempotencyKeyReused
+			default:
+				output.Replayed = true
+				return json.Unmarshal(stored.Response, output)
+			}
+		}
+
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
 
 		accountFrom, err := accountRepository.FindByID(input.AccountIDFrom)
 		if err != nil {
@@ -62,6 +126,11 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTra
 			return err
 		}
 
+		err = spending_limits.Check(transactionRepository, accountFrom, input.Amount, time.Now())
+		if err != nil {
+			return err
+		}
+
 		transaction, err := entity.NewTransaction(accountFrom, accountTo, input.Amount)
 		if err != nil {
 			return err
@@ -87,10 +156,30 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTra
 		output.AccountIDTo = input.AccountIDTo
 		output.Amount = transaction.Amount
 
-		balanceUpdatedOutput.AccountIDFrom = input.AccountIDFrom
-		balanceUpdatedOutput.AccountIDTo = input.AccountIDTo
-		balanceUpdatedOutput.BalanceAccountIDFROM = accountFrom.Balance
-		balanceUpdatedOutput.BalanceAccountIDTO = accountTo.Balance
+		balanceUpdatedPayload.AccountIDFrom = input.AccountIDFrom
+		balanceUpdatedPayload.AccountIDTo = input.AccountIDTo
+		balanceUpdatedPayload.BalanceAccountIDFrom = accountFrom.Balance
+		balanceUpdatedPayload.BalanceAccountIDTo = accountTo.Balance
+		balanceUpdatedPayload.VersionAccountIDFrom = accountFrom.BalanceVersion
+		balanceUpdatedPayload.VersionAccountIDTo = accountTo.BalanceVersion
+		if transaction.OverdrewAccountFrom() {
+			belowZero = &event.BalanceBelowZeroPayload{
+				AccountID:     accountFrom.ID,
+				ClientID:      accountFrom.Client.ID,
+				TransactionID: transaction.ID,
+				Balance:       accountFrom.Balance,
+				Limit:         accountFrom.Limit(),
+			}
+		}
+
+		if input.IdempotencyKey != "" {
+			response, err := json.Marshal(output)
+			if err != nil {
+				return err
+			}
+			key := entity.NewIdempotencyKey(input.IdempotencyKey, requestHash, response, uc.IdempotencyKeyTTL)
+			return uc.getIdempotencyKeyRepository(ctx, tx).Save(key)
+		}
 
 		return nil
 	})
@@ -98,18 +187,36 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTra
 	if err != nil {
 		return nil, err
 	}
+	if output.Replayed {
+		return output, nil
+	}
 
-	uc.transactionCreated.SetPayload(output)
-	uc.EventDispatcher.Dispatch(uc.transactionCreated)
+	transactionCreated := event.NewTransactionCreatedEvent(ctx, event.TransactionCreatedPayload{
+		ID:            output.ID,
+		AccountIDFrom: output.AccountIDFrom,
+		AccountIDTo:   output.AccountIDTo,
+		Amount:        output.Amount,
 
-	uc.balanceUpdated.SetPayload(balanceUpdatedOutput)
-	uc.EventDispatcher.Dispatch(uc.balanceUpdated)
+		BalanceAccountIDFrom: balanceUpdatedPayload.BalanceAccountIDFrom,
+		BalanceAccountIDTo:   balanceUpdatedPayload.BalanceAccountIDTo,
+	})
+	events.DispatchOrLog(uc.EventDispatcher, transactionCreated)
+
+	ctx = events.WithCausationID(ctx, transactionCreated.ID)
+	events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceUpdatedEvent(ctx, output.ID, balanceUpdatedPayload))
+	if belowZero != nil {
+		events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceBelowZeroEvent(ctx, *belowZero))
+	}
 
 	return output, nil
 }
 
-func (uc *CreateTransactionUseCase) getAccountRepository(ctx context.Context) gateway.AccountGateway {
-	repo, err := uc.Uow.GetRepository(ctx, "AccountDB")
+func (uc *CreateTransactionUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
 	if err != nil {
 		panic(err)
 	}
@@ -120,8 +227,8 @@ func (uc *CreateTransactionUseCase) getAccountRepository(ctx context.Context) ga
 	return accountRepo
 }
 
-func (uc *CreateTransactionUseCase) getTransactionRepository(ctx context.Context) gateway.TransactionGateway {
-	repo, err := uc.Uow.GetRepository(ctx, "TransactionDB")
+func (uc *CreateTransactionUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
 	if err != nil {
 		panic(err)
 	}
@@ -131,3 +238,22 @@ func (uc *CreateTransactionUseCase) getTransactionRepository(ctx context.Context
 	}
 	return transactionRepo
 }
+
+func (uc *CreateTransactionUseCase) getIdempotencyKeyRepository(ctx context.Context, tx *uow.Uow) gateway.IdempotencyKeyGateway {
+	repo, err := tx.GetRepository(ctx, "IdempotencyKeyDB")
+	if err != nil {
+		panic(err)
+	}
+	idempotencyKeyRepo, ok := repo.(gateway.IdempotencyKeyGateway)
+	if !ok {
+		panic("repository is not of type IdempotencyKeyGateway")
+	}
+	return idempotencyKeyRepo
+}
+
+// hash identifies the request an idempotency key was first used with.
+func (input CreateTransactionInputDTO) hash() string {
+	body, _ := json.Marshal(input)
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
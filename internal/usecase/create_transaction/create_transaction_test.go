@@ -2,12 +2,20 @@ package create_transaction
 
 import (
 	"context"
+	"database/sql"
+	"math"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/guimartiins/eda-go/internal/database"
 	"github.com/guimartiins/eda-go/internal/entity"
 	"github.com/guimartiins/eda-go/internal/event"
 	"github.com/guimartiins/eda-go/internal/usecase/mocks"
 	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -22,6 +30,31 @@ func (m *TransactionGatewayMock) Create(transaction *entity.Transaction) error {
 	return args.Error(0)
 }
 
+func (m *TransactionGatewayMock) UpdateStatus(transaction *entity.Transaction, previousStatus string, previousReversedAmount float64) error {
+	args := m.Called(transaction, previousStatus, previousReversedAmount)
+	return args.Error(0)
+}
+
+func (m *TransactionGatewayMock) FindExpired(now time.Time, limit int) ([]*entity.Transaction, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) OutgoingUsage(accountID string, since time.Time) (entity.SpendingUsage, error) {
+	args := m.Called(accountID, since)
+	return args.Get(0).(entity.SpendingUsage), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindByID(id string) (*entity.Transaction, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Transaction), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindSince(since time.Time) ([]*entity.Transaction, error) {
+	args := m.Called(since)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
 type AccountGatewayMock struct {
 	mock.Mock
 }
@@ -36,18 +69,51 @@ func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
 	return args.Get(0).(*entity.Account), args.Error(1)
 }
 
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
 func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
 	args := m.Called(account)
 	return args.Error(0)
 }
 
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
 type CreateTransactionUseCaseTestSuite struct {
 	suite.Suite
-	ctx      context.Context
-	mockUow  *mocks.UowMock
-	useCase  *CreateTransactionUseCase
-	account1 *entity.Account
-	account2 *entity.Account
+	ctx        context.Context
+	mockUow    *mocks.UowMock
+	dispatcher *events.EventDispatcher
+	useCase    *CreateTransactionUseCase
+	account1   *entity.Account
+	account2   *entity.Account
 }
 
 func (suite *CreateTransactionUseCaseTestSuite) SetupTest() {
@@ -55,8 +121,6 @@ func (suite *CreateTransactionUseCaseTestSuite) SetupTest() {
 	suite.account1 = entity.NewAccount(client1)
 	suite.account1.Credit(1000)
 	dispatcher := events.NewEventDispatcher()
-	eventTransaction := event.NewTransactionCreatedEvent()
-	eventBalance := event.NewBalanceUpdatedEvent()
 
 	client2, _ := entity.NewClient("client2", "client2@email.com")
 	suite.account2 = entity.NewAccount(client2)
@@ -67,8 +131,9 @@ func (suite *CreateTransactionUseCaseTestSuite) SetupTest() {
 	ctx := context.Background()
 
 	suite.mockUow = mockUow
+	suite.dispatcher = dispatcher
 	suite.ctx = ctx
-	suite.useCase = NewCreateTransactionUseCase(mockUow, dispatcher, eventTransaction, eventBalance)
+	suite.useCase = NewCreateTransactionUseCase(mockUow, dispatcher)
 }
 
 func (suite *CreateTransactionUseCaseTestSuite) TestExecute_SuccessfulTransaction() {
@@ -103,6 +168,297 @@ func (suite *CreateTransactionUseCaseTestSuite) TestExecute_InsufficientFunds()
 	assert.Equal(suite.T(), "insufficient funds", err.Error())
 }
 
+func (suite *CreateTransactionUseCaseTestSuite) TestExecute_DispatchesNewEventPerCall() {
+	recorder := &EventRecorder{}
+	suite.dispatcher.Register(event.TransactionCreatedName, recorder)
+	suite.mockUow.On("Do", mock.Anything, mock.Anything).Return(nil)
+
+	inputDto := CreateTransactionInputDTO{
+		AccountIDFrom: suite.account1.ID,
+		AccountIDTo:   suite.account2.ID,
+		Amount:        100,
+	}
+	first, _ := suite.useCase.Execute(suite.ctx, inputDto)
+	second, _ := suite.useCase.Execute(suite.ctx, inputDto)
+
+	suite.Len(recorder.events, 2)
+	suite.NotSame(recorder.events[0], recorder.events[1])
+	suite.IsType(&event.TransactionCreated{}, recorder.events[0])
+	suite.Equal(first.ID, recorder.events[0].(*event.TransactionCreated).Payload.ID)
+	suite.Equal(second.ID, recorder.events[1].(*event.TransactionCreated).Payload.ID)
+}
+
+func TestCreateTransactionInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, CreateTransactionInputDTO{AccountIDFrom: "a", AccountIDTo: "b", Amount: 10}.Validate())
+
+	tests := []struct {
+		input  CreateTransactionInputDTO
+		fields []string
+	}{
+		{CreateTransactionInputDTO{Amount: 10}, []string{"account_id_from", "account_id_to"}},
+		{CreateTransactionInputDTO{AccountIDFrom: "a", AccountIDTo: "a", Amount: 10}, []string{"account_id_to"}},
+		{CreateTransactionInputDTO{AccountIDFrom: "a", AccountIDTo: "b", Amount: -1}, []string{"amount"}},
+		{CreateTransactionInputDTO{AccountIDFrom: "a", AccountIDTo: "b", Amount: math.NaN()}, []string{"amount"}},
+		{CreateTransactionInputDTO{AccountIDFrom: "a", AccountIDTo: "a"}, []string{"account_id_to", "amount"}},
+	}
+	for _, test := range tests {
+		var errs validation.Errors
+		assert.ErrorAs(t, test.input.Validate(), &errs)
+		var fields []string
+		for _, fieldError := range errs {
+			fields = append(fields, fieldError.Field)
+		}
+		assert.Equal(t, test.fields, fields)
+	}
+}
+
 func TestCreateTransactionUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(CreateTransactionUseCaseTestSuite))
 }
+
+// IdempotencyTestSuite runs the use case against a real unit of work, since
+// the idempotency key has to be read and written in the transaction of the
+// transfer.
+type IdempotencyTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	db       *sql.DB
+	recorder *EventRecorder
+	useCase  *CreateTransactionUseCase
+	account1 *entity.Account
+	account2 *entity.Account
+}
+
+func (s *IdempotencyTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+	s.db.Exec("CREATE TABLE idempotency_keys (idempotency_key varchar(255) PRIMARY KEY, request_hash char(64), response blob, created_at datetime, expires_at datetime)")
+
+	client, _ := entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	s.account1 = entity.NewAccount(client)
+	s.account1.Credit(1000)
+	s.account2 = entity.NewAccount(client)
+	accounts := database.NewAccountDB(db)
+	s.Nil(accounts.Save(s.account1))
+	s.Nil(accounts.Save(s.account2))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+	u.Register("IdempotencyKeyDB", func(tx *sql.Tx) interface{} { return database.NewIdempotencyKeyDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.TransactionCreatedName, s.recorder)
+	s.useCase = NewCreateTransactionUseCase(u, dispatcher)
+}
+
+func (s *IdempotencyTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *IdempotencyTestSuite) countTransactions() int {
+	var count int
+	s.Nil(s.db.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&count))
+	return count
+}
+
+func (s *IdempotencyTestSuite) TestExecute_ReplaysSameRequest() {
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  s.account1.ID,
+		AccountIDTo:    s.account2.ID,
+		Amount:         100,
+		IdempotencyKey: "key",
+	}
+
+	first, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+	s.False(first.Replayed)
+	second, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+
+	s.True(second.Replayed)
+	s.Equal(first.ID, second.ID)
+	s.Equal(first.Amount, second.Amount)
+	s.Equal(1, s.countTransactions())
+	s.Len(s.recorder.events, 1)
+}
+
+func (s *IdempotencyTestSuite) TestExecute_RejectsKeyReusedForAnotherRequest() {
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  s.account1.ID,
+		AccountIDTo:    s.account2.ID,
+		Amount:         100,
+		IdempotencyKey: "key",
+	}
+	_, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+
+	input.Amount = 200
+	_, err = s.useCase.Execute(s.ctx, input)
+
+	s.ErrorIs(err, ErrIdempotencyKeyReused)
+	s.Equal(1, s.countTransactions())
+}
+
+func (s *IdempotencyTestSuite) TestExecute_ExpiredKeyCreatesNewTransaction() {
+	s.useCase.IdempotencyKeyTTL = -time.Second
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  s.account1.ID,
+		AccountIDTo:    s.account2.ID,
+		Amount:         100,
+		IdempotencyKey: "key",
+	}
+
+	first, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+	second, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+
+	s.False(second.Replayed)
+	s.NotEqual(first.ID, second.ID)
+	s.Equal(2, s.countTransactions())
+}
+
+func (s *IdempotencyTestSuite) TestExecute_FailedTransferDoesNotStoreKey() {
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  s.account1.ID,
+		AccountIDTo:    s.account2.ID,
+		Amount:         5000,
+		IdempotencyKey: "key",
+	}
+	_, err := s.useCase.Execute(s.ctx, input)
+	s.ErrorIs(err, entity.ErrInsufficientFunds)
+
+	input.Amount = 100
+	output, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+	s.False(output.Replayed)
+}
+
+// racingIdempotencyKeyDB misses the stored keys on its first misses finds, as
+// a request does when a concurrent one with the same key commits after it
+// looked the key up.
+type racingIdempotencyKeyDB struct {
+	*database.IdempotencyKeyDB
+	misses *int
+}
+
+func (r racingIdempotencyKeyDB) Find(key string) (*entity.IdempotencyKey, error) {
+	if *r.misses > 0 {
+		*r.misses--
+		return nil, sql.ErrNoRows
+	}
+	return r.IdempotencyKeyDB.Find(key)
+}
+
+func (s *IdempotencyTestSuite) TestExecute_ReplaysConcurrentRequest() {
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  s.account1.ID,
+		AccountIDTo:    s.account2.ID,
+		Amount:         100,
+		IdempotencyKey: "key",
+	}
+	first, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+
+	misses := 1
+	s.useCase.Uow.Register("IdempotencyKeyDB", func(tx *sql.Tx) interface{} {
+		return racingIdempotencyKeyDB{IdempotencyKeyDB: database.NewIdempotencyKeyDB(tx), misses: &misses}
+	})
+	second, err := s.useCase.Execute(s.ctx, input)
+	s.Nil(err)
+
+	s.True(second.Replayed)
+	s.Equal(first.ID, second.ID)
+	s.Equal(1, s.countTransactions())
+	s.Len(s.recorder.events, 1)
+	account, err := database.NewAccountDB(s.db).FindByID(s.account1.ID)
+	s.Nil(err)
+	s.Equal(900.0, account.Balance)
+}
+
+func TestIdempotencyTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
+
+// SpendingLimitsTestSuite runs the use case against a real unit of work, as
+// the spending limits are checked against the transfers stored in it.
+type SpendingLimitsTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	db       *sql.DB
+	useCase  *CreateTransactionUseCase
+	client   *entity.Client
+	account1 *entity.Account
+	account2 *entity.Account
+}
+
+func (s *SpendingLimitsTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+
+	s.client, _ = entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, daily_count_limit, created_at) VALUES (?, ?, ?, ?, ?)", s.client.ID, s.client.Name, s.client.Email, 2, s.client.CreatedAt)
+	s.account1 = entity.NewAccount(s.client)
+	s.account1.Credit(1000)
+	s.account1.SpendingLimits = entity.SpendingLimits{DailyAmount: 250}
+	s.account2 = entity.NewAccount(s.client)
+	accounts := database.NewAccountDB(db)
+	s.Nil(accounts.Save(s.account1))
+	s.Nil(accounts.Save(s.account2))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+	s.useCase = NewCreateTransactionUseCase(u, events.NewEventDispatcher())
+}
+
+func (s *SpendingLimitsTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *SpendingLimitsTestSuite) TestExecute_DailyAmountOfAccount() {
+	_, err := s.useCase.Execute(s.ctx, CreateTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 200})
+	s.Nil(err)
+
+	_, err = s.useCase.Execute(s.ctx, CreateTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 60})
+
+	var limitErr *entity.LimitExceededError
+	s.ErrorAs(err, &limitErr)
+	s.ErrorIs(err, entity.ErrLimitExceeded)
+	s.Equal(entity.LimitDailyAmount, limitErr.Limit)
+	s.Equal(50.0, limitErr.Remaining)
+
+	var balance float64
+	s.Nil(s.db.QueryRow("SELECT balance FROM accounts WHERE id = ?", s.account1.ID).Scan(&balance))
+	s.Equal(800.0, balance)
+}
+
+func (s *SpendingLimitsTestSuite) TestExecute_DailyCountOfClient() {
+	for range 2 {
+		_, err := s.useCase.Execute(s.ctx, CreateTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 10})
+		s.Nil(err)
+	}
+
+	_, err := s.useCase.Execute(s.ctx, CreateTransactionInputDTO{AccountIDFrom: s.account1.ID, AccountIDTo: s.account2.ID, Amount: 10})
+
+	s.Equal(&entity.LimitExceededError{Limit: entity.LimitDailyCount, Remaining: 0}, err)
+}
+
+func TestSpendingLimitsTestSuite(t *testing.T) {
+	suite.Run(t, new(SpendingLimitsTestSuite))
+}
@@ -0,0 +1,180 @@
+package create_withdrawal
+
+import (
+	"context"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/internal/usecase/spending_limits"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+type CreateWithdrawalInputDTO struct {
+	AccountID string  `json:"-"`
+	Amount    float64 `json:"amount"`
+}
+
+func (input CreateWithdrawalInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("account_id", input.AccountID)
+	errs.Positive("amount", input.Amount)
+	return errs.Err()
+}
+
+type CreateWithdrawalOutputDTO struct {
+	ID        string  `json:"id"`
+	AccountID string  `json:"account_id"`
+	Amount    float64 `json:"amount"`
+	Balance   float64 `json:"balance"`
+}
+
+// CreateWithdrawalUseCase moves money out of the system, debiting the account
+// and crediting the settlement account.
+type CreateWithdrawalUseCase struct {
+	Uow                 uow.UowInterface
+	EventDispatcher     events.EventDispatcherInterface
+	SettlementAccountID string
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+}
+
+func NewCreateWithdrawalUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface, settlementAccountID string) *CreateWithdrawalUseCase {
+	return &CreateWithdrawalUseCase{
+		Uow:                 Uow,
+		EventDispatcher:     eventDispatcher,
+		SettlementAccountID: settlementAccountID,
+	}
+}
+
+func (uc *CreateWithdrawalUseCase) Execute(ctx context.Context, input CreateWithdrawalInputDTO) (*CreateWithdrawalOutputDTO, error) {
+	output := &CreateWithdrawalOutputDTO{}
+	payload := event.WithdrawalCompletedPayload{}
+	balanceUpdated := event.BalanceUpdatedPayload{}
+	var belowZero *event.BalanceBelowZeroPayload
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
+
+		err := accountRepository.Lock(uc.SettlementAccountID, input.AccountID)
+		if err != nil {
+			return err
+		}
+
+		settlement, err := accountRepository.FindByID(uc.SettlementAccountID)
+		if err != nil {
+			return err
+		}
+
+		account, err := accountRepository.FindByID(input.AccountID)
+		if err != nil {
+			return err
+		}
+
+		err = spending_limits.Check(transactionRepository, account, input.Amount, time.Now())
+		if err != nil {
+			return err
+		}
+
+		withdrawal, err := entity.NewWithdrawal(account, settlement, input.Amount)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(settlement)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(account)
+		if err != nil {
+			return err
+		}
+
+		err = transactionRepository.Create(withdrawal)
+		if err != nil {
+			return err
+		}
+
+		output.ID = withdrawal.ID
+		output.AccountID = account.ID
+		output.Amount = withdrawal.Amount
+		output.Balance = account.Balance
+
+		payload = event.WithdrawalCompletedPayload{
+			ID:                  withdrawal.ID,
+			AccountID:           account.ID,
+			SettlementAccountID: settlement.ID,
+			Amount:              withdrawal.Amount,
+			Balance:             account.Balance,
+			SettlementBalance:   settlement.Balance,
+		}
+		balanceUpdated = event.BalanceUpdatedPayload{
+			AccountIDFrom:        account.ID,
+			AccountIDTo:          settlement.ID,
+			BalanceAccountIDFrom: account.Balance,
+			BalanceAccountIDTo:   settlement.Balance,
+			VersionAccountIDFrom: account.BalanceVersion,
+			VersionAccountIDTo:   settlement.BalanceVersion,
+		}
+		if withdrawal.OverdrewAccountFrom() {
+			belowZero = &event.BalanceBelowZeroPayload{
+				AccountID:     account.ID,
+				ClientID:      account.Client.ID,
+				TransactionID: withdrawal.ID,
+				Balance:       account.Balance,
+				Limit:         account.Limit(),
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawalCompleted := event.NewWithdrawalCompletedEvent(ctx, payload)
+	events.DispatchOrLog(uc.EventDispatcher, withdrawalCompleted)
+
+	ctx = events.WithCausationID(ctx, withdrawalCompleted.ID)
+	events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceUpdatedEvent(ctx, output.ID, balanceUpdated))
+
+	if belowZero != nil {
+		events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceBelowZeroEvent(ctx, *belowZero))
+	}
+
+	return output, nil
+}
+
+func (uc *CreateWithdrawalUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
+
+func (uc *CreateWithdrawalUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
+	if err != nil {
+		panic(err)
+	}
+	transactionRepo, ok := repo.(gateway.TransactionGateway)
+	if !ok {
+		panic("repository is not of type TransactionGateway")
+	}
+	return transactionRepo
+}
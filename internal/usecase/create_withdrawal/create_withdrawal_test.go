@@ -0,0 +1,174 @@
+package create_withdrawal
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type CreateWithdrawalUseCaseTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	db         *sql.DB
+	accounts   *database.AccountDB
+	recorder   *EventRecorder
+	useCase    *CreateWithdrawalUseCase
+	settlement *entity.Account
+	account    *entity.Account
+}
+
+func (s *CreateWithdrawalUseCaseTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+
+	client, _ := entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	s.settlement = entity.NewAccount(client)
+	s.account = entity.NewAccount(client)
+	s.account.Credit(100)
+	s.accounts = database.NewAccountDB(db)
+	s.Nil(s.accounts.Save(s.settlement))
+	s.Nil(s.accounts.Save(s.account))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.WithdrawalCompletedName, s.recorder)
+	dispatcher.Register(event.BalanceUpdatedName, s.recorder)
+	dispatcher.Register(event.BalanceBelowZeroName, s.recorder)
+	s.useCase = NewCreateWithdrawalUseCase(u, dispatcher, s.settlement.ID)
+}
+
+func (s *CreateWithdrawalUseCaseTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *CreateWithdrawalUseCaseTestSuite) TestExecute() {
+	output, err := s.useCase.Execute(s.ctx, CreateWithdrawalInputDTO{AccountID: s.account.ID, Amount: 40})
+	s.Nil(err)
+	s.NotEmpty(output.ID)
+	s.Equal(s.account.ID, output.AccountID)
+	s.Equal(40.0, output.Amount)
+	s.Equal(60.0, output.Balance)
+
+	account, err := s.accounts.FindByID(s.account.ID)
+	s.Nil(err)
+	s.Equal(60.0, account.Balance)
+	settlement, err := s.accounts.FindByID(s.settlement.ID)
+	s.Nil(err)
+	s.Equal(40.0, settlement.Balance)
+
+	var transactionType string
+	s.Nil(s.db.QueryRow("SELECT type FROM transactions WHERE id = ?", output.ID).Scan(&transactionType))
+	s.Equal(entity.TransactionWithdrawal, transactionType)
+
+	s.Len(s.recorder.events, 2)
+	completed := s.recorder.events[0].(*event.WithdrawalCompleted)
+	s.Equal(output.ID, completed.Payload.ID)
+	s.Equal(s.settlement.ID, completed.Payload.SettlementAccountID)
+	s.Equal(40.0, completed.Payload.SettlementBalance)
+	updated := s.recorder.events[1].(*event.BalanceUpdated)
+	s.Equal(completed.ID, updated.CausationID)
+	s.Equal(s.account.ID, updated.Payload.AccountIDFrom)
+	s.Equal(60.0, updated.Payload.BalanceAccountIDFrom)
+	s.Equal(int64(2), updated.Payload.VersionAccountIDFrom)
+	s.Equal(int64(1), updated.Payload.VersionAccountIDTo)
+}
+
+func (s *CreateWithdrawalUseCaseTestSuite) TestExecute_InsufficientFunds() {
+	output, err := s.useCase.Execute(s.ctx, CreateWithdrawalInputDTO{AccountID: s.account.ID, Amount: 150})
+	s.ErrorIs(err, entity.ErrInsufficientFunds)
+	s.Nil(output)
+	s.Empty(s.recorder.events)
+
+	account, err := s.accounts.FindByID(s.account.ID)
+	s.Nil(err)
+	s.Equal(100.0, account.Balance)
+}
+
+func (s *CreateWithdrawalUseCaseTestSuite) TestExecute_OverdraftLimit() {
+	_, err := s.db.Exec("UPDATE accounts SET overdraft_limit = 100 WHERE id = ?", s.account.ID)
+	s.Nil(err)
+
+	output, err := s.useCase.Execute(s.ctx, CreateWithdrawalInputDTO{AccountID: s.account.ID, Amount: 150})
+	s.Nil(err)
+	s.Equal(-50.0, output.Balance)
+
+	s.Len(s.recorder.events, 3)
+	belowZero := s.recorder.events[2].(*event.BalanceBelowZero)
+	s.Equal(s.account.ID, belowZero.Payload.AccountID)
+	s.Equal(output.ID, belowZero.Payload.TransactionID)
+	s.Equal(-50.0, belowZero.Payload.Balance)
+	s.Equal(100.0, belowZero.Payload.Limit)
+	s.Equal(s.recorder.events[0].(*event.WithdrawalCompleted).ID, belowZero.CausationID)
+
+	_, err = s.useCase.Execute(s.ctx, CreateWithdrawalInputDTO{AccountID: s.account.ID, Amount: 20})
+	s.Nil(err)
+	s.Len(s.recorder.events, 5, "an account already below zero is not reported again")
+
+	_, err = s.useCase.Execute(s.ctx, CreateWithdrawalInputDTO{AccountID: s.account.ID, Amount: 40})
+	s.ErrorIs(err, entity.ErrInsufficientFunds)
+}
+
+func (s *CreateWithdrawalUseCaseTestSuite) TestExecute_SpendingLimitExceeded() {
+	s.account.SpendingLimits = entity.SpendingLimits{DailyCount: 1}
+	s.Nil(s.accounts.UpdateSpendingLimits(s.account))
+	_, err := s.useCase.Execute(s.ctx, CreateWithdrawalInputDTO{AccountID: s.account.ID, Amount: 10})
+	s.Nil(err)
+
+	output, err := s.useCase.Execute(s.ctx, CreateWithdrawalInputDTO{AccountID: s.account.ID, Amount: 10})
+	var limitErr *entity.LimitExceededError
+	s.ErrorAs(err, &limitErr)
+	s.Equal(entity.LimitDailyCount, limitErr.Limit)
+	s.Nil(output)
+
+	account, err := s.accounts.FindByID(s.account.ID)
+	s.Nil(err)
+	s.Equal(90.0, account.Balance)
+}
+
+func TestCreateWithdrawalInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, CreateWithdrawalInputDTO{AccountID: "a", Amount: 10}.Validate())
+
+	var errs validation.Errors
+	assert.ErrorAs(t, CreateWithdrawalInputDTO{Amount: -1}.Validate(), &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "account_id", errs[0].Field)
+	assert.Equal(t, "amount", errs[1].Field)
+}
+
+func TestCreateWithdrawalUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(CreateWithdrawalUseCaseTestSuite))
+}
@@ -0,0 +1,128 @@
+package delete_client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+)
+
+type DeleteClientInputDTO struct {
+	ID string
+}
+
+// DeleteClientUseCase soft deletes a client and closes its accounts, in one
+// unit of work. A client is only deleted while every one of its accounts has
+// a zero balance and no funds held: an account funded after it was read
+// fails its close, and the whole deletion with it.
+type DeleteClientUseCase struct {
+	Uow             uow.UowInterface
+	EventDispatcher events.EventDispatcherInterface
+}
+
+func NewDeleteClientUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface) *DeleteClientUseCase {
+	return &DeleteClientUseCase{
+		Uow:             Uow,
+		EventDispatcher: eventDispatcher,
+	}
+}
+
+func (uc *DeleteClientUseCase) Execute(ctx context.Context, input DeleteClientInputDTO) error {
+	deleted := event.ClientDeletedPayload{}
+	var closed []event.AccountStatusChangedPayload
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		clientRepository := uc.getClientRepository(ctx, tx)
+		accountRepository := uc.getAccountRepository(ctx, tx)
+
+		client, err := clientRepository.Get(input.ID)
+		if err != nil {
+			return err
+		}
+
+		accounts, err := accountRepository.FindByClientID(client.ID)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			if err := client.AddAccount(account); err != nil {
+				return err
+			}
+		}
+
+		err = client.Delete()
+		if err != nil {
+			return err
+		}
+
+		for _, account := range accounts {
+			if account.Status == entity.AccountClosed {
+				continue
+			}
+			previousStatus := account.Status
+			err = account.Close(entity.ReasonClientDeleted)
+			if err != nil {
+				return err
+			}
+			err = accountRepository.UpdateStatus(account, previousStatus)
+			if errors.Is(err, entity.ErrAccountNotEmpty) {
+				return entity.ErrClientHasFunds
+			}
+			if err != nil {
+				return err
+			}
+			closed = append(closed, event.AccountStatusChangedPayload{
+				AccountID:      account.ID,
+				ClientID:       client.ID,
+				Status:         account.Status,
+				PreviousStatus: previousStatus,
+				Reason:         account.StatusReason,
+			})
+		}
+
+		deleted.ClientID = client.ID
+		deleted.DeletedAt = client.DeletedAt
+		return clientRepository.Delete(client)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	clientDeleted := event.NewClientDeletedEvent(ctx, deleted)
+	events.DispatchOrLog(uc.EventDispatcher, clientDeleted)
+
+	ctx = events.WithCausationID(ctx, clientDeleted.ID)
+	for _, payload := range closed {
+		events.DispatchOrLog(uc.EventDispatcher, event.NewAccountStatusChangedEvent(ctx, payload))
+	}
+
+	return nil
+}
+
+func (uc *DeleteClientUseCase) getClientRepository(ctx context.Context, tx *uow.Uow) gateway.ClientGateway {
+	repo, err := tx.GetRepository(ctx, "ClientDB")
+	if err != nil {
+		panic(err)
+	}
+	clientRepo, ok := repo.(gateway.ClientGateway)
+	if !ok {
+		panic("repository is not of type ClientGateway")
+	}
+	return clientRepo
+}
+
+func (uc *DeleteClientUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	repo, err := tx.GetRepository(ctx, "AccountDB")
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
@@ -0,0 +1,128 @@
+package delete_client
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type DeleteClientUseCaseTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	db       *sql.DB
+	clients  *database.ClientDB
+	accounts *database.AccountDB
+	recorder *EventRecorder
+	useCase  *DeleteClientUseCase
+	client   *entity.Client
+	account1 *entity.Account
+	account2 *entity.Account
+}
+
+func (s *DeleteClientUseCaseTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date, updated_at date, deleted_at datetime)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+
+	s.client, _ = entity.NewClient("client1", "client1@email.com")
+	s.account1 = entity.NewAccount(s.client)
+	s.account2 = entity.NewAccount(s.client)
+	s.Nil(s.account2.Freeze(entity.FreezeAll, entity.ReasonFraudSuspected))
+	s.clients = database.NewClientDB(db)
+	s.accounts = database.NewAccountDB(db)
+	s.Nil(s.clients.Save(s.client))
+	s.Nil(s.accounts.Save(s.account1))
+	s.Nil(s.accounts.Save(s.account2))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("ClientDB", func(tx *sql.Tx) interface{} { return database.NewClientDB(tx) })
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.ClientDeletedName, s.recorder)
+	dispatcher.Register(event.AccountStatusChangedName, s.recorder)
+	s.useCase = NewDeleteClientUseCase(u, dispatcher)
+}
+
+func (s *DeleteClientUseCaseTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *DeleteClientUseCaseTestSuite) TestExecute() {
+	err := s.useCase.Execute(s.ctx, DeleteClientInputDTO{ID: s.client.ID})
+	s.Nil(err)
+
+	_, err = s.clients.Get(s.client.ID)
+	s.ErrorIs(err, sql.ErrNoRows)
+	for _, id := range []string{s.account1.ID, s.account2.ID} {
+		account, err := s.accounts.FindByID(id)
+		s.Nil(err)
+		s.Equal(entity.AccountClosed, account.Status)
+		s.Equal(entity.ReasonClientDeleted, account.StatusReason)
+	}
+
+	s.Len(s.recorder.events, 3)
+	deleted := s.recorder.events[0].(*event.ClientDeleted)
+	s.Equal(s.client.ID, deleted.AggregateID)
+	s.False(deleted.Payload.DeletedAt.IsZero())
+	closed := s.recorder.events[2].(*event.AccountStatusChanged)
+	s.Equal(deleted.ID, closed.CausationID)
+	s.Equal(event.AccountStatusChangedPayload{
+		AccountID:      s.account2.ID,
+		ClientID:       s.client.ID,
+		Status:         entity.AccountClosed,
+		PreviousStatus: entity.AccountFrozen,
+		Reason:         entity.ReasonClientDeleted,
+	}, closed.Payload)
+}
+
+func (s *DeleteClientUseCaseTestSuite) TestExecute_ClientHasFunds() {
+	s.account2.Credit(10)
+	s.Nil(s.accounts.UpdateBalance(s.account2))
+
+	err := s.useCase.Execute(s.ctx, DeleteClientInputDTO{ID: s.client.ID})
+	s.ErrorIs(err, entity.ErrClientHasFunds)
+	s.Empty(s.recorder.events)
+
+	_, err = s.clients.Get(s.client.ID)
+	s.Nil(err)
+	account, err := s.accounts.FindByID(s.account1.ID)
+	s.Nil(err)
+	s.Equal(entity.AccountActive, account.Status)
+}
+
+func (s *DeleteClientUseCaseTestSuite) TestExecute_ClientNotFound() {
+	err := s.useCase.Execute(s.ctx, DeleteClientInputDTO{ID: "unknown"})
+	s.ErrorIs(err, sql.ErrNoRows)
+	s.Empty(s.recorder.events)
+}
+
+func TestDeleteClientUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(DeleteClientUseCaseTestSuite))
+}
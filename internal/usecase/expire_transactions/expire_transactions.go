@@ -0,0 +1,154 @@
+package expire_transactions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+)
+
+// DefaultLimit is the number of transactions expired by one Execute.
+const DefaultLimit = 100
+
+type ExpireTransactionsInputDTO struct {
+	// Now is compared to the expiry of the holds. A zero Now means the
+	// current time.
+	Now   time.Time
+	Limit int
+}
+
+type ExpireTransactionsOutputDTO struct {
+	Expired int
+}
+
+// ExpireTransactionsUseCase releases the holds of authorized transactions
+// past their expiry. Each transaction is expired in its own unit of work, and
+// the ones captured or voided meanwhile are skipped.
+type ExpireTransactionsUseCase struct {
+	Uow                uow.UowInterface
+	TransactionGateway gateway.TransactionGateway
+	EventDispatcher    events.EventDispatcherInterface
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+}
+
+func NewExpireTransactionsUseCase(Uow uow.UowInterface, transactionGateway gateway.TransactionGateway, eventDispatcher events.EventDispatcherInterface) *ExpireTransactionsUseCase {
+	return &ExpireTransactionsUseCase{
+		Uow:                Uow,
+		TransactionGateway: transactionGateway,
+		EventDispatcher:    eventDispatcher,
+	}
+}
+
+func (uc *ExpireTransactionsUseCase) Execute(ctx context.Context, input ExpireTransactionsInputDTO) (*ExpireTransactionsOutputDTO, error) {
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	candidates, err := uc.TransactionGateway.FindExpired(now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ExpireTransactionsOutputDTO{}
+	for _, candidate := range candidates {
+		transaction, err := uc.expire(ctx, candidate.ID)
+		if errors.Is(err, entity.ErrInvalidTransition) || errors.Is(err, gateway.ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return output, err
+		}
+		output.Expired++
+
+		events.DispatchOrLog(uc.EventDispatcher, event.NewTransactionExpiredEvent(ctx, event.TransactionLifecyclePayload{
+			ID:                            transaction.ID,
+			Status:                        transaction.Status,
+			AccountIDFrom:                 transaction.AccountFrom.ID,
+			AccountIDTo:                   transaction.AccountTo.ID,
+			Amount:                        transaction.Amount,
+			ExpiresAt:                     transaction.ExpiresAt,
+			BalanceAccountIDFrom:          transaction.AccountFrom.Balance,
+			AvailableBalanceAccountIDFrom: transaction.AccountFrom.AvailableBalance(),
+		}))
+	}
+
+	return output, nil
+}
+
+func (uc *ExpireTransactionsUseCase) expire(ctx context.Context, id string) (*entity.Transaction, error) {
+	var transaction *entity.Transaction
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
+
+		var err error
+		transaction, err = transactionRepository.FindByID(id)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.Lock(transaction.AccountFrom.ID)
+		if err != nil {
+			return err
+		}
+
+		transaction.AccountFrom, err = accountRepository.FindByID(transaction.AccountFrom.ID)
+		if err != nil {
+			return err
+		}
+
+		previousStatus := transaction.Status
+		err = transaction.Expire()
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(transaction.AccountFrom)
+		if err != nil {
+			return err
+		}
+
+		return transactionRepository.UpdateStatus(transaction, previousStatus, transaction.ReversedAmount)
+	})
+	return transaction, err
+}
+
+func (uc *ExpireTransactionsUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
+
+func (uc *ExpireTransactionsUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
+	if err != nil {
+		panic(err)
+	}
+	transactionRepo, ok := repo.(gateway.TransactionGateway)
+	if !ok {
+		panic("repository is not of type TransactionGateway")
+	}
+	return transactionRepo
+}
@@ -0,0 +1,159 @@
+package expire_transactions
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionGatewayMock struct {
+	mock.Mock
+}
+
+func (m *TransactionGatewayMock) Create(transaction *entity.Transaction) error {
+	args := m.Called(transaction)
+	return args.Error(0)
+}
+
+func (m *TransactionGatewayMock) UpdateStatus(transaction *entity.Transaction, previousStatus string, previousReversedAmount float64) error {
+	args := m.Called(transaction, previousStatus, previousReversedAmount)
+	return args.Error(0)
+}
+
+func (m *TransactionGatewayMock) FindExpired(now time.Time, limit int) ([]*entity.Transaction, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) OutgoingUsage(accountID string, since time.Time) (entity.SpendingUsage, error) {
+	args := m.Called(accountID, since)
+	return args.Get(0).(entity.SpendingUsage), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindByID(id string) (*entity.Transaction, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Transaction), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindSince(since time.Time) ([]*entity.Transaction, error) {
+	args := m.Called(since)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type ExpireTransactionsUseCaseTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	db           *sql.DB
+	accounts     *database.AccountDB
+	transactions *database.TransactionDB
+	recorder     *EventRecorder
+	useCase      *ExpireTransactionsUseCase
+	transaction  *entity.Transaction
+}
+
+func (s *ExpireTransactionsUseCaseTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+
+	client, _ := entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	sender := entity.NewAccount(client)
+	sender.Credit(100)
+	recipient := entity.NewAccount(client)
+	s.transaction = entity.NewPendingTransaction(sender, recipient, 60)
+	s.Nil(s.transaction.Authorize(time.Now().Add(-time.Minute)))
+	s.accounts = database.NewAccountDB(db)
+	s.transactions = database.NewTransactionDB(db)
+	s.Nil(s.accounts.Save(sender))
+	s.Nil(s.accounts.Save(recipient))
+	s.Nil(s.transactions.Create(s.transaction))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.RegisterPattern("*", s.recorder)
+	s.useCase = NewExpireTransactionsUseCase(u, s.transactions, dispatcher)
+}
+
+func (s *ExpireTransactionsUseCaseTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ExpireTransactionsUseCaseTestSuite) account(id string) *entity.Account {
+	account, err := s.accounts.FindByID(id)
+	s.Nil(err)
+	return account
+}
+
+func (s *ExpireTransactionsUseCaseTestSuite) TestExecute() {
+	active := entity.NewPendingTransaction(s.transaction.AccountFrom, s.transaction.AccountTo, 10)
+	s.Nil(active.Authorize(time.Now().Add(time.Hour)))
+	s.Nil(s.accounts.UpdateBalance(active.AccountFrom))
+	s.Nil(s.transactions.Create(active))
+
+	output, err := s.useCase.Execute(s.ctx, ExpireTransactionsInputDTO{})
+	s.Nil(err)
+	s.Equal(1, output.Expired)
+
+	expired, err := s.transactions.FindByID(s.transaction.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionExpired, expired.Status)
+	s.Equal(10.0, s.account(s.transaction.AccountFrom.ID).Held)
+
+	s.Len(s.recorder.events, 1)
+	s.Equal(event.TransactionExpiredName, s.recorder.events[0].GetName())
+
+	output, err = s.useCase.Execute(s.ctx, ExpireTransactionsInputDTO{})
+	s.Nil(err)
+	s.Equal(0, output.Expired)
+}
+
+func (s *ExpireTransactionsUseCaseTestSuite) TestExecute_SkipsTransactionsCapturedMeanwhile() {
+	stale := &TransactionGatewayMock{}
+	stale.On("FindExpired", mock.Anything, DefaultLimit).Return([]*entity.Transaction{s.transaction}, nil)
+	s.useCase.TransactionGateway = stale
+	_, err := s.db.Exec("UPDATE transactions SET status = ? WHERE id = ?", entity.TransactionSettled, s.transaction.ID)
+	s.Nil(err)
+
+	output, err := s.useCase.Execute(s.ctx, ExpireTransactionsInputDTO{})
+	s.Nil(err)
+	s.Equal(0, output.Expired)
+	s.Empty(s.recorder.events)
+	s.Equal(60.0, s.account(s.transaction.AccountFrom.ID).Held)
+}
+
+func TestExpireTransactionsUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ExpireTransactionsUseCaseTestSuite))
+}
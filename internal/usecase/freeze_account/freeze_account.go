@@ -0,0 +1,91 @@
+package freeze_account
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+type FreezeAccountInputDTO struct {
+	AccountID string `json:"-"`
+	// Mode is one of the entity freeze modes, the DefaultMode of the use
+	// case when empty.
+	Mode   string `json:"mode"`
+	Reason string `json:"reason"`
+}
+
+func (input FreezeAccountInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("account_id", input.AccountID)
+	if input.Mode != "" {
+		errs.OneOf("mode", input.Mode, entity.FreezeModes)
+	}
+	if errs.Required("reason", input.Reason) {
+		errs.OneOf("reason", input.Reason, entity.StatusReasons)
+	}
+	return errs.Err()
+}
+
+type FreezeAccountOutputDTO struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`
+	FreezeMode string `json:"freeze_mode"`
+	Reason     string `json:"reason"`
+}
+
+type FreezeAccountUseCase struct {
+	AccountGateway  gateway.AccountGateway
+	EventDispatcher events.EventDispatcherInterface
+	// DefaultMode is the freeze mode of requests without one.
+	DefaultMode string
+}
+
+func NewFreezeAccountUseCase(accountGateway gateway.AccountGateway, eventDispatcher events.EventDispatcherInterface) *FreezeAccountUseCase {
+	return &FreezeAccountUseCase{
+		AccountGateway:  accountGateway,
+		EventDispatcher: eventDispatcher,
+		DefaultMode:     entity.FreezeOutgoing,
+	}
+}
+
+func (uc *FreezeAccountUseCase) Execute(ctx context.Context, input FreezeAccountInputDTO) (*FreezeAccountOutputDTO, error) {
+	account, err := uc.AccountGateway.FindByID(input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := input.Mode
+	if mode == "" {
+		mode = uc.DefaultMode
+	}
+	previousStatus := account.Status
+	err = account.Freeze(mode, input.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.AccountGateway.UpdateStatus(account, previousStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	events.DispatchOrLog(uc.EventDispatcher, event.NewAccountStatusChangedEvent(ctx, event.AccountStatusChangedPayload{
+		AccountID:      account.ID,
+		ClientID:       account.Client.ID,
+		Status:         account.Status,
+		PreviousStatus: previousStatus,
+		FreezeMode:     account.FreezeMode,
+		Reason:         account.StatusReason,
+	}))
+
+	return &FreezeAccountOutputDTO{
+		ID:         account.ID,
+		Status:     account.Status,
+		FreezeMode: account.FreezeMode,
+		Reason:     account.StatusReason,
+	}, nil
+}
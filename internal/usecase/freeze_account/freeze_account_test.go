@@ -0,0 +1,161 @@
+package freeze_account
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	account, _ := args.Get(0).(*entity.Account)
+	return account, args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+func newRecordingDispatcher() (*events.EventDispatcher, *EventRecorder) {
+	recorder := &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.AccountStatusChangedName, recorder)
+	return dispatcher, recorder
+}
+
+func TestFreezeAccountUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	m.On("UpdateStatus", account, entity.AccountActive).Return(nil)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewFreezeAccountUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonFraudSuspected})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.AccountFrozen, output.Status)
+	assert.Equal(t, entity.FreezeOutgoing, output.FreezeMode)
+	assert.Equal(t, entity.ReasonFraudSuspected, output.Reason)
+	m.AssertExpectations(t)
+
+	assert.Len(t, recorder.events, 1)
+	changed := recorder.events[0].(*event.AccountStatusChanged)
+	assert.Equal(t, account.ID, changed.AggregateID)
+	assert.Equal(t, event.AccountStatusChangedPayload{
+		AccountID:      account.ID,
+		ClientID:       client.ID,
+		Status:         entity.AccountFrozen,
+		PreviousStatus: entity.AccountActive,
+		FreezeMode:     entity.FreezeOutgoing,
+		Reason:         entity.ReasonFraudSuspected,
+	}, changed.Payload)
+}
+
+func TestFreezeAccountUseCase_Execute_DefaultMode(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	m.On("UpdateStatus", account, entity.AccountActive).Return(nil)
+	dispatcher, _ := newRecordingDispatcher()
+
+	uc := NewFreezeAccountUseCase(m, dispatcher)
+	uc.DefaultMode = entity.FreezeAll
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonLegalOrder})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.FreezeAll, output.FreezeMode)
+}
+
+func TestFreezeAccountUseCase_Execute_ConcurrentChange(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	m.On("UpdateStatus", account, entity.AccountActive).Return(gateway.ErrVersionConflict)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewFreezeAccountUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonFraudSuspected})
+
+	assert.ErrorIs(t, err, gateway.ErrVersionConflict)
+	assert.Nil(t, output)
+	assert.Empty(t, recorder.events)
+}
+
+func TestFreezeAccountUseCase_Execute_AlreadyFrozen(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	assert.Nil(t, account.Freeze(entity.FreezeAll, entity.ReasonFraudSuspected))
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	dispatcher, _ := newRecordingDispatcher()
+
+	uc := NewFreezeAccountUseCase(m, dispatcher)
+	_, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonFraudSuspected})
+
+	assert.ErrorIs(t, err, entity.ErrInvalidAccountTransition)
+	m.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestFreezeAccountInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, FreezeAccountInputDTO{AccountID: "a", Reason: entity.ReasonOther}.Validate())
+
+	var errs validation.Errors
+	assert.ErrorAs(t, FreezeAccountInputDTO{AccountID: "a", Mode: "incoming", Reason: "bored"}.Validate(), &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "mode", errs[0].Field)
+	assert.Equal(t, "reason", errs[1].Field)
+}
@@ -0,0 +1,64 @@
+package get_account
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+type GetAccountInputDTO struct {
+	ID string
+}
+
+type GetAccountOutputDTO struct {
+	ID       string  `json:"id"`
+	ClientID string  `json:"client_id"`
+	Balance  float64 `json:"balance"`
+	// AvailableBalance is Balance minus the funds held by authorizations.
+	AvailableBalance float64 `json:"available_balance"`
+	// OverdraftLimit is how far below zero the balance may go, on top of
+	// the credit line of the client.
+	OverdraftLimit float64 `json:"overdraft_limit"`
+	// SpendingLimits are the limits of the account, with the unset ones
+	// taken from the client.
+	SpendingLimits entity.SpendingLimits `json:"spending_limits"`
+	Status         string                `json:"status"`
+	// FreezeMode is set while the account is frozen.
+	FreezeMode string `json:"freeze_mode,omitempty"`
+	// StatusReason is the reason code of the last status change.
+	StatusReason string    `json:"status_reason,omitempty"`
+	Currency     string    `json:"currency"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type GetAccountUseCase struct {
+	AccountGateway gateway.AccountGateway
+}
+
+func NewGetAccountUseCase(accountGateway gateway.AccountGateway) *GetAccountUseCase {
+	return &GetAccountUseCase{
+		AccountGateway: accountGateway,
+	}
+}
+
+func (u *GetAccountUseCase) Execute(input GetAccountInputDTO) (*GetAccountOutputDTO, error) {
+	account, err := u.AccountGateway.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAccountOutputDTO{
+		ID:               account.ID,
+		ClientID:         account.Client.ID,
+		Balance:          account.Balance,
+		AvailableBalance: account.AvailableBalance(),
+		OverdraftLimit:   account.OverdraftLimit,
+		SpendingLimits:   account.EffectiveSpendingLimits(),
+		Status:           account.Status,
+		FreezeMode:       account.FreezeMode,
+		StatusReason:     account.StatusReason,
+		Currency:         account.Currency,
+		CreatedAt:        account.CreatedAt,
+	}, nil
+}
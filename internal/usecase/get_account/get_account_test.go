@@ -0,0 +1,79 @@
+package get_account
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	account, _ := args.Get(0).(*entity.Account)
+	return account, args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+func TestGetAccountUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	account.Credit(100)
+	account.Hold("t1", 30)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+
+	uc := NewGetAccountUseCase(m)
+	output, err := uc.Execute(GetAccountInputDTO{ID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, client.ID, output.ClientID)
+	assert.Equal(t, 100.0, output.Balance)
+	assert.Equal(t, 70.0, output.AvailableBalance)
+}
+
+func TestGetAccountUseCase_Execute_NotFound(t *testing.T) {
+	m := &AccountGatewayMock{}
+	m.On("FindByID", "unknown").Return(nil, sql.ErrNoRows)
+
+	uc := NewGetAccountUseCase(m)
+	output, err := uc.Execute(GetAccountInputDTO{ID: "unknown"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, output)
+}
@@ -0,0 +1,50 @@
+package get_client
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+type GetClientInputDTO struct {
+	ID string
+}
+
+type GetClientOutputDTO struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Email      string  `json:"email"`
+	CreditLine float64 `json:"credit_line"`
+	// SpendingLimits are the defaults of the accounts of the client.
+	SpendingLimits entity.SpendingLimits `json:"spending_limits"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+type GetClientUseCase struct {
+	ClientGateway gateway.ClientGateway
+}
+
+func NewGetClientUseCase(clientGateway gateway.ClientGateway) *GetClientUseCase {
+	return &GetClientUseCase{
+		ClientGateway: clientGateway,
+	}
+}
+
+func (u *GetClientUseCase) Execute(input GetClientInputDTO) (*GetClientOutputDTO, error) {
+	client, err := u.ClientGateway.Get(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetClientOutputDTO{
+		ID:             client.ID,
+		Name:           client.Name,
+		Email:          client.Email,
+		CreditLine:     client.CreditLine,
+		SpendingLimits: client.SpendingLimits,
+		CreatedAt:      client.CreatedAt,
+		UpdatedAt:      client.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package get_client
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ClientGatewayMock struct {
+	mock.Mock
+}
+
+func (m *ClientGatewayMock) Get(id string) (*entity.Client, error) {
+	args := m.Called(id)
+	client, _ := args.Get(0).(*entity.Client)
+	return client, args.Error(1)
+}
+
+func (m *ClientGatewayMock) Save(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Update(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Delete(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*entity.Client), args.Error(1)
+}
+
+func TestGetClientUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	m := &ClientGatewayMock{}
+	m.On("Get", client.ID).Return(client, nil)
+
+	uc := NewGetClientUseCase(m)
+	output, err := uc.Execute(GetClientInputDTO{ID: client.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, client.ID, output.ID)
+	assert.Equal(t, "John Doe", output.Name)
+	assert.Equal(t, "j@j.com", output.Email)
+	assert.Equal(t, client.CreatedAt, output.CreatedAt)
+}
+
+func TestGetClientUseCase_Execute_NotFound(t *testing.T) {
+	m := &ClientGatewayMock{}
+	m.On("Get", "unknown").Return(nil, sql.ErrNoRows)
+
+	uc := NewGetClientUseCase(m)
+	output, err := uc.Execute(GetClientInputDTO{ID: "unknown"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, output)
+}
@@ -0,0 +1,56 @@
+package get_transaction
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+type GetTransactionInputDTO struct {
+	ID string
+}
+
+type GetTransactionOutputDTO struct {
+	ID             string  `json:"id"`
+	Type           string  `json:"type"`
+	Status         string  `json:"status"`
+	AccountIDFrom  string  `json:"account_id_from"`
+	AccountIDTo    string  `json:"account_id_to"`
+	Amount         float64 `json:"amount"`
+	ReversedAmount float64 `json:"reversed_amount"`
+	// OriginalTransactionID is only set on reversals.
+	OriginalTransactionID string `json:"original_transaction_id,omitempty"`
+	// ExpiresAt is only set on authorizations.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetTransactionUseCase struct {
+	TransactionGateway gateway.TransactionGateway
+}
+
+func NewGetTransactionUseCase(transactionGateway gateway.TransactionGateway) *GetTransactionUseCase {
+	return &GetTransactionUseCase{
+		TransactionGateway: transactionGateway,
+	}
+}
+
+func (u *GetTransactionUseCase) Execute(input GetTransactionInputDTO) (*GetTransactionOutputDTO, error) {
+	transaction, err := u.TransactionGateway.FindByID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetTransactionOutputDTO{
+		ID:                    transaction.ID,
+		Type:                  transaction.Type,
+		Status:                transaction.Status,
+		AccountIDFrom:         transaction.AccountFrom.ID,
+		AccountIDTo:           transaction.AccountTo.ID,
+		Amount:                transaction.Amount,
+		ReversedAmount:        transaction.ReversedAmount,
+		OriginalTransactionID: transaction.OriginalTransactionID,
+		ExpiresAt:             transaction.ExpiresAt,
+		CreatedAt:             transaction.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package get_transaction
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type TransactionGatewayMock struct {
+	mock.Mock
+}
+
+func (m *TransactionGatewayMock) Create(transaction *entity.Transaction) error {
+	args := m.Called(transaction)
+	return args.Error(0)
+}
+
+func (m *TransactionGatewayMock) UpdateStatus(transaction *entity.Transaction, previousStatus string, previousReversedAmount float64) error {
+	args := m.Called(transaction, previousStatus, previousReversedAmount)
+	return args.Error(0)
+}
+
+func (m *TransactionGatewayMock) FindExpired(now time.Time, limit int) ([]*entity.Transaction, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) OutgoingUsage(accountID string, since time.Time) (entity.SpendingUsage, error) {
+	args := m.Called(accountID, since)
+	return args.Get(0).(entity.SpendingUsage), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindByID(id string) (*entity.Transaction, error) {
+	args := m.Called(id)
+	transaction, _ := args.Get(0).(*entity.Transaction)
+	return transaction, args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindSince(since time.Time) ([]*entity.Transaction, error) {
+	args := m.Called(since)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+func TestGetTransactionUseCase_Execute(t *testing.T) {
+	transaction := &entity.Transaction{
+		ID:          "tx",
+		AccountFrom: &entity.Account{ID: "from"},
+		AccountTo:   &entity.Account{ID: "to"},
+		Amount:      10,
+		CreatedAt:   time.Now(),
+	}
+	m := &TransactionGatewayMock{}
+	m.On("FindByID", "tx").Return(transaction, nil)
+
+	uc := NewGetTransactionUseCase(m)
+	output, err := uc.Execute(GetTransactionInputDTO{ID: "tx"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "tx", output.ID)
+	assert.Equal(t, "from", output.AccountIDFrom)
+	assert.Equal(t, "to", output.AccountIDTo)
+	assert.Equal(t, 10.0, output.Amount)
+}
+
+func TestGetTransactionUseCase_Execute_NotFound(t *testing.T) {
+	m := &TransactionGatewayMock{}
+	m.On("FindByID", "unknown").Return(nil, sql.ErrNoRows)
+
+	uc := NewGetTransactionUseCase(m)
+	output, err := uc.Execute(GetTransactionInputDTO{ID: "unknown"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, output)
+}
@@ -0,0 +1,106 @@
+package list_account_transactions
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type ListAccountTransactionsInputDTO struct {
+	AccountID string
+	// From and To bound the time of the listed movements; To is exclusive.
+	From time.Time
+	To   time.Time
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+type StatementLineOutputDTO struct {
+	ID                    string    `json:"id"`
+	TransactionID         string    `json:"transaction_id"`
+	Direction             string    `json:"direction"`
+	CounterpartyAccountID string    `json:"counterparty_account_id"`
+	Amount                float64   `json:"amount"`
+	Balance               float64   `json:"balance"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+type ListAccountTransactionsOutputDTO struct {
+	Transactions []StatementLineOutputDTO `json:"transactions"`
+	NextCursor   string                   `json:"next_cursor,omitempty"`
+}
+
+// ListAccountTransactionsUseCase pages through an account statement, newest
+// movement first.
+type ListAccountTransactionsUseCase struct {
+	StatementGateway gateway.StatementGateway
+}
+
+func NewListAccountTransactionsUseCase(statementGateway gateway.StatementGateway) *ListAccountTransactionsUseCase {
+	return &ListAccountTransactionsUseCase{
+		StatementGateway: statementGateway,
+	}
+}
+
+func (uc *ListAccountTransactionsUseCase) Execute(input ListAccountTransactionsInputDTO) (*ListAccountTransactionsOutputDTO, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	filter := gateway.StatementFilter{
+		From:  input.From,
+		To:    input.To,
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		after, err := uc.StatementGateway.FindByID(input.Cursor)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidCursor
+		}
+		if err != nil {
+			return nil, err
+		}
+		if after.AccountID != input.AccountID {
+			return nil, ErrInvalidCursor
+		}
+		filter.After = after
+	}
+
+	lines, err := uc.StatementGateway.FindByAccountID(input.AccountID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListAccountTransactionsOutputDTO{Transactions: []StatementLineOutputDTO{}}
+	if len(lines) > limit {
+		lines = lines[:limit]
+		output.NextCursor = lines[limit-1].ID
+	}
+	for _, line := range lines {
+		output.Transactions = append(output.Transactions, StatementLineOutputDTO{
+			ID:                    line.ID,
+			TransactionID:         line.TransactionID,
+			Direction:             line.Direction,
+			CounterpartyAccountID: line.CounterpartyAccountID,
+			Amount:                line.Amount,
+			Balance:               line.Balance,
+			CreatedAt:             line.CreatedAt,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,86 @@
+package list_account_transactions
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type StatementGatewayMock struct {
+	mock.Mock
+}
+
+func (m *StatementGatewayMock) Append(line *entity.StatementLine) error {
+	args := m.Called(line)
+	return args.Error(0)
+}
+
+func (m *StatementGatewayMock) FindByAccountID(accountID string, filter gateway.StatementFilter) ([]*entity.StatementLine, error) {
+	args := m.Called(accountID, filter)
+	return args.Get(0).([]*entity.StatementLine), args.Error(1)
+}
+
+func (m *StatementGatewayMock) FindByID(id string) (*entity.StatementLine, error) {
+	args := m.Called(id)
+	line, _ := args.Get(0).(*entity.StatementLine)
+	return line, args.Error(1)
+}
+
+func statementLines(accountID string, n int) []*entity.StatementLine {
+	var lines []*entity.StatementLine
+	now := time.Now()
+	for i := 0; i < n; i++ {
+		lines = append(lines, entity.NewStatementLines("tx", accountID, "other", 10, 100-float64(i)*10, 10, now.Add(-time.Duration(i)*time.Minute))[0])
+	}
+	return lines
+}
+
+func TestListAccountTransactionsUseCase_Execute(t *testing.T) {
+	lines := statementLines("account", 3)
+	m := &StatementGatewayMock{}
+	m.On("FindByAccountID", "account", gateway.StatementFilter{Limit: 3}).Return(lines, nil)
+
+	uc := NewListAccountTransactionsUseCase(m)
+	output, err := uc.Execute(ListAccountTransactionsInputDTO{AccountID: "account", Limit: 2})
+
+	assert.Nil(t, err)
+	assert.Len(t, output.Transactions, 2)
+	assert.Equal(t, lines[1].ID, output.NextCursor)
+	assert.Equal(t, entity.StatementDebit, output.Transactions[0].Direction)
+	assert.Equal(t, "other", output.Transactions[0].CounterpartyAccountID)
+	assert.Equal(t, 100.0, output.Transactions[0].Balance)
+	assert.Equal(t, 90.0, output.Transactions[1].Balance)
+}
+
+func TestListAccountTransactionsUseCase_Execute_LastPage(t *testing.T) {
+	lines := statementLines("account", 3)
+	m := &StatementGatewayMock{}
+	m.On("FindByID", lines[0].ID).Return(lines[0], nil)
+	m.On("FindByAccountID", "account", gateway.StatementFilter{After: lines[0], Limit: DefaultLimit + 1}).Return(lines[1:], nil)
+
+	uc := NewListAccountTransactionsUseCase(m)
+	output, err := uc.Execute(ListAccountTransactionsInputDTO{AccountID: "account", Cursor: lines[0].ID})
+
+	assert.Nil(t, err)
+	assert.Len(t, output.Transactions, 2)
+	assert.Empty(t, output.NextCursor)
+}
+
+func TestListAccountTransactionsUseCase_Execute_InvalidCursor(t *testing.T) {
+	otherAccount := statementLines("other-account", 1)[0]
+	m := &StatementGatewayMock{}
+	m.On("FindByID", "unknown").Return(nil, sql.ErrNoRows)
+	m.On("FindByID", otherAccount.ID).Return(otherAccount, nil)
+
+	uc := NewListAccountTransactionsUseCase(m)
+	_, err := uc.Execute(ListAccountTransactionsInputDTO{AccountID: "account", Cursor: "unknown"})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = uc.Execute(ListAccountTransactionsInputDTO{AccountID: "account", Cursor: otherAccount.ID})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	m.AssertNotCalled(t, "FindByAccountID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,68 @@
+package list_client_accounts
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+type ListClientAccountsInputDTO struct {
+	ClientID string
+}
+
+type AccountOutputDTO struct {
+	ID               string    `json:"id"`
+	Balance          float64   `json:"balance"`
+	AvailableBalance float64   `json:"available_balance"`
+	OverdraftLimit   float64   `json:"overdraft_limit"`
+	Status           string    `json:"status"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type ListClientAccountsOutputDTO struct {
+	ClientID string             `json:"client_id"`
+	Accounts []AccountOutputDTO `json:"accounts"`
+}
+
+type ListClientAccountsUseCase struct {
+	ClientGateway  gateway.ClientGateway
+	AccountGateway gateway.AccountGateway
+}
+
+func NewListClientAccountsUseCase(clientGateway gateway.ClientGateway, accountGateway gateway.AccountGateway) *ListClientAccountsUseCase {
+	return &ListClientAccountsUseCase{
+		ClientGateway:  clientGateway,
+		AccountGateway: accountGateway,
+	}
+}
+
+// Execute fails with the error of ClientGateway.Get when the client does not
+// exist, so that an unknown client is told apart from one without accounts.
+func (u *ListClientAccountsUseCase) Execute(input ListClientAccountsInputDTO) (*ListClientAccountsOutputDTO, error) {
+	client, err := u.ClientGateway.Get(input.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := u.AccountGateway.FindByClientID(client.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListClientAccountsOutputDTO{
+		ClientID: client.ID,
+		Accounts: []AccountOutputDTO{},
+	}
+	for _, account := range accounts {
+		output.Accounts = append(output.Accounts, AccountOutputDTO{
+			ID:               account.ID,
+			Balance:          account.Balance,
+			AvailableBalance: account.AvailableBalance(),
+			OverdraftLimit:   account.OverdraftLimit,
+			Status:           account.Status,
+			CreatedAt:        account.CreatedAt,
+		})
+	}
+
+	return output, nil
+}
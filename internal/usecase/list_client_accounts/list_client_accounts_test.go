@@ -0,0 +1,113 @@
+package list_client_accounts
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ClientGatewayMock struct {
+	mock.Mock
+}
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *ClientGatewayMock) Get(id string) (*entity.Client, error) {
+	args := m.Called(id)
+	client, _ := args.Get(0).(*entity.Client)
+	return client, args.Error(1)
+}
+
+func (m *ClientGatewayMock) Save(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Update(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Delete(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*entity.Client), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+func TestListClientAccountsUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account1 := entity.NewAccount(client)
+	account2 := entity.NewAccount(client)
+	account2.Credit(50)
+
+	cm := &ClientGatewayMock{}
+	cm.On("Get", client.ID).Return(client, nil)
+	am := &AccountGatewayMock{}
+	am.On("FindByClientID", client.ID).Return([]*entity.Account{account1, account2}, nil)
+
+	uc := NewListClientAccountsUseCase(cm, am)
+	output, err := uc.Execute(ListClientAccountsInputDTO{ClientID: client.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, client.ID, output.ClientID)
+	assert.Len(t, output.Accounts, 2)
+	assert.Equal(t, account1.ID, output.Accounts[0].ID)
+	assert.Equal(t, 50.0, output.Accounts[1].Balance)
+}
+
+func TestListClientAccountsUseCase_Execute_ClientNotFound(t *testing.T) {
+	cm := &ClientGatewayMock{}
+	cm.On("Get", "unknown").Return(nil, sql.ErrNoRows)
+	am := &AccountGatewayMock{}
+
+	uc := NewListClientAccountsUseCase(cm, am)
+	_, err := uc.Execute(ListClientAccountsInputDTO{ClientID: "unknown"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	am.AssertNotCalled(t, "FindByClientID", mock.Anything)
+}
@@ -0,0 +1,95 @@
+package list_clients
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type ListClientsInputDTO struct {
+	// Query, when set, matches part of the name or email of the clients.
+	Query string
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+type ClientOutputDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListClientsOutputDTO struct {
+	Clients    []ClientOutputDTO `json:"clients"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// ListClientsUseCase pages through the clients, newest first.
+type ListClientsUseCase struct {
+	ClientGateway gateway.ClientGateway
+}
+
+func NewListClientsUseCase(clientGateway gateway.ClientGateway) *ListClientsUseCase {
+	return &ListClientsUseCase{
+		ClientGateway: clientGateway,
+	}
+}
+
+func (uc *ListClientsUseCase) Execute(input ListClientsInputDTO) (*ListClientsOutputDTO, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	filter := gateway.ClientFilter{
+		Query: input.Query,
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		after, err := uc.ClientGateway.Get(input.Cursor)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidCursor
+		}
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	clients, err := uc.ClientGateway.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListClientsOutputDTO{Clients: []ClientOutputDTO{}}
+	if len(clients) > limit {
+		clients = clients[:limit]
+		output.NextCursor = clients[limit-1].ID
+	}
+	for _, client := range clients {
+		output.Clients = append(output.Clients, ClientOutputDTO{
+			ID:        client.ID,
+			Name:      client.Name,
+			Email:     client.Email,
+			CreatedAt: client.CreatedAt,
+			UpdatedAt: client.UpdatedAt,
+		})
+	}
+
+	return output, nil
+}
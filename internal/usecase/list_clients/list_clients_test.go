@@ -0,0 +1,87 @@
+package list_clients
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ClientGatewayMock struct {
+	mock.Mock
+}
+
+func (m *ClientGatewayMock) Get(id string) (*entity.Client, error) {
+	args := m.Called(id)
+	client, _ := args.Get(0).(*entity.Client)
+	return client, args.Error(1)
+}
+
+func (m *ClientGatewayMock) Save(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Update(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Delete(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*entity.Client), args.Error(1)
+}
+
+func TestListClientsUseCase_Execute(t *testing.T) {
+	after, _ := entity.NewClient("Ana", "ana@j.com")
+	var clients []*entity.Client
+	for _, name := range []string{"John Doe", "Jane Doe", "Jim Doe"} {
+		client, _ := entity.NewClient(name, "doe@j.com")
+		clients = append(clients, client)
+	}
+	m := &ClientGatewayMock{}
+	m.On("Get", after.ID).Return(after, nil)
+	m.On("List", gateway.ClientFilter{Query: "doe", After: after, Limit: 3}).Return(clients, nil)
+
+	uc := NewListClientsUseCase(m)
+	output, err := uc.Execute(ListClientsInputDTO{Query: "doe", Cursor: after.ID, Limit: 2})
+
+	assert.Nil(t, err)
+	assert.Len(t, output.Clients, 2)
+	assert.Equal(t, "John Doe", output.Clients[0].Name)
+	assert.Equal(t, clients[1].ID, output.NextCursor)
+	m.AssertExpectations(t)
+}
+
+func TestListClientsUseCase_Execute_LastPage(t *testing.T) {
+	m := &ClientGatewayMock{}
+	m.On("List", gateway.ClientFilter{Limit: DefaultLimit + 1}).Return([]*entity.Client(nil), nil)
+
+	uc := NewListClientsUseCase(m)
+	output, err := uc.Execute(ListClientsInputDTO{})
+
+	assert.Nil(t, err)
+	assert.Empty(t, output.Clients)
+	assert.NotNil(t, output.Clients)
+	assert.Empty(t, output.NextCursor)
+}
+
+func TestListClientsUseCase_Execute_InvalidCursor(t *testing.T) {
+	m := &ClientGatewayMock{}
+	m.On("Get", "unknown").Return(nil, sql.ErrNoRows)
+
+	uc := NewListClientsUseCase(m)
+	output, err := uc.Execute(ListClientsInputDTO{Cursor: "unknown"})
+
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	assert.Nil(t, output)
+	m.AssertNotCalled(t, "List", mock.Anything)
+}
@@ -0,0 +1,290 @@
+package replay_transactions
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+type ReplayTransactionsInputDTO struct {
+	// From and To bound the time the replayed transactions settled at,
+	// which is when they were captured for authorized transfers. A zero To
+	// means up to now.
+	From time.Time
+	To   time.Time
+	// FromID and ToID, when set, narrow the range to the transactions
+	// between these two, inclusive, in settlement order.
+	FromID        string
+	ToID          string
+	DryRun        bool
+	RatePerSecond float64
+}
+
+type ReplayTransactionsOutputDTO struct {
+	Transactions int
+	Events       int
+	// LastTransactionID is the last transaction replayed. When Execute
+	// fails, the replay can resume from the one after it.
+	LastTransactionID string
+}
+
+// ReplayTransactionsUseCase regenerates the TransactionCreated, or
+// TransactionCaptured, DepositReceived, WithdrawalCompleted and
+// TransactionReversed, and BalanceUpdated events of stored transactions, at
+// the time they settled. The balances of each event are derived backwards
+// from the current balance of the accounts, so every transaction after From
+// is read even when To is set. Holds are not replayed, so a replayed
+// TransactionCaptured carries the balance of the sender as its available
+// balance.
+type ReplayTransactionsUseCase struct {
+	TransactionGateway gateway.TransactionGateway
+	AccountGateway     gateway.AccountGateway
+	EventDispatcher    events.EventDispatcherInterface
+}
+
+func NewReplayTransactionsUseCase(transactionGateway gateway.TransactionGateway, accountGateway gateway.AccountGateway, eventDispatcher events.EventDispatcherInterface) *ReplayTransactionsUseCase {
+	return &ReplayTransactionsUseCase{
+		TransactionGateway: transactionGateway,
+		AccountGateway:     accountGateway,
+		EventDispatcher:    eventDispatcher,
+	}
+}
+
+func (uc *ReplayTransactionsUseCase) Execute(ctx context.Context, input ReplayTransactionsInputDTO) (*ReplayTransactionsOutputDTO, error) {
+	stored, err := uc.TransactionGateway.FindSince(input.From)
+	if err != nil {
+		return nil, err
+	}
+	// Authorizations that were never captured moved no money.
+	transactions := make([]*entity.Transaction, 0, len(stored))
+	for _, transaction := range stored {
+		if transaction.MovedFunds() {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	balances, err := uc.balancesAfter(transactions)
+	if err != nil {
+		return nil, err
+	}
+	originals := originalsAfter(transactions)
+
+	var throttle <-chan time.Time
+	if input.RatePerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / input.RatePerSecond))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	ctx = events.WithCorrelationID(ctx, "replay-"+uuid.New().String())
+	output := &ReplayTransactionsOutputDTO{}
+	started := input.FromID == ""
+	for i, transaction := range transactions {
+		if !input.To.IsZero() && transaction.SettledAt().After(input.To) {
+			break
+		}
+		if !started {
+			if transaction.ID != input.FromID {
+				continue
+			}
+			started = true
+		}
+
+		if !input.DryRun {
+			if throttle != nil {
+				select {
+				case <-throttle:
+				case <-ctx.Done():
+					return output, ctx.Err()
+				}
+			}
+			if err := uc.dispatch(ctx, transaction, balances[i], originals[transaction.ID]); err != nil {
+				return output, err
+			}
+		}
+		output.Transactions++
+		output.Events += 2
+		output.LastTransactionID = transaction.ID
+
+		if transaction.ID == input.ToID {
+			break
+		}
+	}
+
+	return output, nil
+}
+
+type resultingBalances struct {
+	from        float64
+	to          float64
+	fromVersion int64
+	toVersion   int64
+}
+
+type accountBalance struct {
+	balance float64
+	version int64
+}
+
+// balancesAfter walks transactions backwards from the current balances to
+// find the balance of both accounts right after each transaction. Each
+// transaction changed the balance of both accounts once, so their balance
+// versions are walked back the same way; versions older than the first one
+// the account had are left zero.
+func (uc *ReplayTransactionsUseCase) balancesAfter(transactions []*entity.Transaction) ([]resultingBalances, error) {
+	current := make(map[string]accountBalance)
+	balanceOf := func(accountID string) (accountBalance, error) {
+		if balance, ok := current[accountID]; ok {
+			return balance, nil
+		}
+		account, err := uc.AccountGateway.FindByID(accountID)
+		if err != nil {
+			return accountBalance{}, err
+		}
+		current[accountID] = accountBalance{balance: account.Balance, version: account.BalanceVersion}
+		return current[accountID], nil
+	}
+
+	result := make([]resultingBalances, len(transactions))
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+		from, err := balanceOf(transaction.AccountFrom.ID)
+		if err != nil {
+			return nil, err
+		}
+		to, err := balanceOf(transaction.AccountTo.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = resultingBalances{from: from.balance, to: to.balance, fromVersion: max(0, from.version), toVersion: max(0, to.version)}
+		current[transaction.AccountFrom.ID] = accountBalance{balance: from.balance + transaction.Amount, version: from.version - 1}
+		current[transaction.AccountTo.ID] = accountBalance{balance: to.balance - transaction.Amount, version: to.version - 1}
+	}
+
+	return result, nil
+}
+
+type originalAfterReversal struct {
+	status         string
+	reversedAmount float64
+}
+
+// originalsAfter finds, for each reversal, the status and reversed amount of
+// its original transaction right after it, keyed by reversal ID. Reversals of
+// transactions outside of transactions are left out.
+func originalsAfter(transactions []*entity.Transaction) map[string]originalAfterReversal {
+	amounts := make(map[string]float64)
+	reversed := make(map[string]float64)
+	result := make(map[string]originalAfterReversal)
+	for _, transaction := range transactions {
+		if transaction.Type != entity.TransactionReversal {
+			amounts[transaction.ID] = transaction.Amount
+			continue
+		}
+		amount, ok := amounts[transaction.OriginalTransactionID]
+		if !ok {
+			continue
+		}
+		reversed[transaction.OriginalTransactionID] += transaction.Amount
+		status := entity.TransactionPartiallyReversed
+		if reversed[transaction.OriginalTransactionID] >= amount {
+			status = entity.TransactionReversed
+		}
+		result[transaction.ID] = originalAfterReversal{status: status, reversedAmount: reversed[transaction.OriginalTransactionID]}
+	}
+	return result
+}
+
+func (uc *ReplayTransactionsUseCase) dispatch(ctx context.Context, transaction *entity.Transaction, balances resultingBalances, original originalAfterReversal) error {
+	transactionEvent, transactionEventID := newTransactionEvent(ctx, transaction, balances, original)
+	if err := uc.EventDispatcher.Dispatch(transactionEvent); err != nil {
+		return err
+	}
+
+	balanceUpdated := event.NewBalanceUpdatedEvent(events.WithCausationID(ctx, transactionEventID), transaction.ID, event.BalanceUpdatedPayload{
+		AccountIDFrom:        transaction.AccountFrom.ID,
+		AccountIDTo:          transaction.AccountTo.ID,
+		BalanceAccountIDFrom: balances.from,
+		BalanceAccountIDTo:   balances.to,
+		VersionAccountIDFrom: balances.fromVersion,
+		VersionAccountIDTo:   balances.toVersion,
+	})
+	balanceUpdated.OccurredAt = transaction.SettledAt()
+	return uc.EventDispatcher.Dispatch(balanceUpdated)
+}
+
+// newTransactionEvent returns the event emitted when transaction moved its
+// funds, depending on its type, along with the event ID.
+func newTransactionEvent(ctx context.Context, transaction *entity.Transaction, balances resultingBalances, original originalAfterReversal) (events.EventInterface, string) {
+	switch transaction.Type {
+	case entity.TransactionReversal:
+		transactionReversed := event.NewTransactionReversedEvent(ctx, event.TransactionReversedPayload{
+			ID:                     transaction.ID,
+			OriginalTransactionID:  transaction.OriginalTransactionID,
+			AccountIDFrom:          transaction.AccountFrom.ID,
+			AccountIDTo:            transaction.AccountTo.ID,
+			Amount:                 transaction.Amount,
+			BalanceAccountIDFrom:   balances.from,
+			BalanceAccountIDTo:     balances.to,
+			OriginalStatus:         original.status,
+			OriginalReversedAmount: original.reversedAmount,
+		})
+		transactionReversed.OccurredAt = transaction.CreatedAt
+		return transactionReversed, transactionReversed.ID
+	case entity.TransactionDeposit:
+		depositReceived := event.NewDepositReceivedEvent(ctx, event.DepositReceivedPayload{
+			ID:                  transaction.ID,
+			AccountID:           transaction.AccountTo.ID,
+			SettlementAccountID: transaction.AccountFrom.ID,
+			Amount:              transaction.Amount,
+			Balance:             balances.to,
+			SettlementBalance:   balances.from,
+		})
+		depositReceived.OccurredAt = transaction.CreatedAt
+		return depositReceived, depositReceived.ID
+	case entity.TransactionWithdrawal:
+		withdrawalCompleted := event.NewWithdrawalCompletedEvent(ctx, event.WithdrawalCompletedPayload{
+			ID:                  transaction.ID,
+			AccountID:           transaction.AccountFrom.ID,
+			SettlementAccountID: transaction.AccountTo.ID,
+			Amount:              transaction.Amount,
+			Balance:             balances.from,
+			SettlementBalance:   balances.to,
+		})
+		withdrawalCompleted.OccurredAt = transaction.CreatedAt
+		return withdrawalCompleted, withdrawalCompleted.ID
+	}
+
+	if !transaction.CapturedAt.IsZero() {
+		transactionCaptured := event.NewTransactionCapturedEvent(ctx, event.TransactionLifecyclePayload{
+			ID:                            transaction.ID,
+			Status:                        entity.TransactionSettled,
+			AccountIDFrom:                 transaction.AccountFrom.ID,
+			AccountIDTo:                   transaction.AccountTo.ID,
+			Amount:                        transaction.Amount,
+			BalanceAccountIDFrom:          balances.from,
+			AvailableBalanceAccountIDFrom: balances.from,
+			BalanceAccountIDTo:            balances.to,
+		})
+		transactionCaptured.OccurredAt = transaction.CapturedAt
+		return transactionCaptured, transactionCaptured.ID
+	}
+
+	transactionCreated := event.NewTransactionCreatedEvent(ctx, event.TransactionCreatedPayload{
+		ID:            transaction.ID,
+		AccountIDFrom: transaction.AccountFrom.ID,
+		AccountIDTo:   transaction.AccountTo.ID,
+		Amount:        transaction.Amount,
+
+		BalanceAccountIDFrom: balances.from,
+		BalanceAccountIDTo:   balances.to,
+	})
+	transactionCreated.OccurredAt = transaction.CreatedAt
+	return transactionCreated, transactionCreated.ID
+}
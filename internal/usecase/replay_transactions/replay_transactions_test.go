@@ -0,0 +1,265 @@
+package replay_transactions
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionGatewayMock struct {
+	mock.Mock
+}
+
+func (m *TransactionGatewayMock) Create(transaction *entity.Transaction) error {
+	args := m.Called(transaction)
+	return args.Error(0)
+}
+
+func (m *TransactionGatewayMock) UpdateStatus(transaction *entity.Transaction, previousStatus string, previousReversedAmount float64) error {
+	args := m.Called(transaction, previousStatus, previousReversedAmount)
+	return args.Error(0)
+}
+
+func (m *TransactionGatewayMock) FindExpired(now time.Time, limit int) ([]*entity.Transaction, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) OutgoingUsage(accountID string, since time.Time) (entity.SpendingUsage, error) {
+	args := m.Called(accountID, since)
+	return args.Get(0).(entity.SpendingUsage), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindByID(id string) (*entity.Transaction, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Transaction), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) FindSince(since time.Time) ([]*entity.Transaction, error) {
+	args := m.Called(since)
+	return args.Get(0).([]*entity.Transaction), args.Error(1)
+}
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type ReplayTransactionsUseCaseTestSuite struct {
+	suite.Suite
+	recorder     *EventRecorder
+	useCase      *ReplayTransactionsUseCase
+	since        time.Time
+	transactions []*entity.Transaction
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) SetupTest() {
+	accountA := &entity.Account{ID: "a", Balance: 70, BalanceVersion: 3}
+	accountB := &entity.Account{ID: "b", Balance: 130, BalanceVersion: 2}
+	suite.since = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.transactions = []*entity.Transaction{
+		{ID: "t1", AccountFrom: accountA, AccountTo: accountB, Amount: 10, CreatedAt: suite.since},
+		{ID: "t2", AccountFrom: accountB, AccountTo: accountA, Amount: 40, CreatedAt: suite.since.Add(time.Minute)},
+		{ID: "t3", AccountFrom: accountA, AccountTo: accountB, Amount: 60, CreatedAt: suite.since.Add(2 * time.Minute)},
+	}
+
+	tm := &TransactionGatewayMock{}
+	tm.On("FindSince", suite.since).Return(suite.transactions, nil)
+	am := &AccountGatewayMock{}
+	am.On("FindByID", "a").Return(accountA, nil)
+	am.On("FindByID", "b").Return(accountB, nil)
+
+	dispatcher := events.NewEventDispatcher()
+	suite.recorder = &EventRecorder{}
+	dispatcher.RegisterPattern("*", suite.recorder)
+
+	suite.useCase = NewReplayTransactionsUseCase(tm, am, dispatcher)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute() {
+	output, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: suite.since})
+	suite.Nil(err)
+	suite.Equal(3, output.Transactions)
+	suite.Equal(6, output.Events)
+	suite.Equal("t3", output.LastTransactionID)
+	suite.Len(suite.recorder.events, 6)
+
+	created := suite.recorder.events[0].(*event.TransactionCreated)
+	suite.Equal("t1", created.Payload.ID)
+	suite.Equal(suite.since, created.GetDateTime())
+
+	balances := []event.BalanceUpdatedPayload{}
+	for _, e := range suite.recorder.events {
+		if balanceUpdated, ok := e.(*event.BalanceUpdated); ok {
+			suite.Equal(created.CorrelationID, balanceUpdated.CorrelationID)
+			balances = append(balances, balanceUpdated.Payload)
+		}
+	}
+	suite.Equal([]event.BalanceUpdatedPayload{
+		{AccountIDFrom: "a", AccountIDTo: "b", BalanceAccountIDFrom: 90, BalanceAccountIDTo: 110, VersionAccountIDFrom: 1},
+		{AccountIDFrom: "b", AccountIDTo: "a", BalanceAccountIDFrom: 70, BalanceAccountIDTo: 130, VersionAccountIDFrom: 1, VersionAccountIDTo: 2},
+		{AccountIDFrom: "a", AccountIDTo: "b", BalanceAccountIDFrom: 70, BalanceAccountIDTo: 130, VersionAccountIDFrom: 3, VersionAccountIDTo: 2},
+	}, balances)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute_IDRange() {
+	output, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: suite.since, FromID: "t2", ToID: "t2"})
+	suite.Nil(err)
+	suite.Equal(1, output.Transactions)
+	suite.Len(suite.recorder.events, 2)
+	suite.Equal("t2", suite.recorder.events[0].(*event.TransactionCreated).Payload.ID)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute_TimeRange() {
+	output, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: suite.since, To: suite.since.Add(time.Minute)})
+	suite.Nil(err)
+	suite.Equal(2, output.Transactions)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute_DryRun() {
+	output, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: suite.since, DryRun: true})
+	suite.Nil(err)
+	suite.Equal(3, output.Transactions)
+	suite.Empty(suite.recorder.events)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute_RateLimited() {
+	start := time.Now()
+	_, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: suite.since, RatePerSecond: 50})
+	suite.Nil(err)
+	suite.GreaterOrEqual(time.Since(start), 60*time.Millisecond)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute_DepositsAndWithdrawals() {
+	settlement := &entity.Account{ID: "s", Balance: -20}
+	account := &entity.Account{ID: "c", Balance: 20}
+	since := suite.since.Add(time.Hour)
+	tm := &TransactionGatewayMock{}
+	tm.On("FindSince", since).Return([]*entity.Transaction{
+		{ID: "d1", Type: entity.TransactionDeposit, AccountFrom: settlement, AccountTo: account, Amount: 50, CreatedAt: since},
+		{ID: "w1", Type: entity.TransactionWithdrawal, AccountFrom: account, AccountTo: settlement, Amount: 30, CreatedAt: since.Add(time.Minute)},
+	}, nil)
+	am := &AccountGatewayMock{}
+	am.On("FindByID", "s").Return(settlement, nil)
+	am.On("FindByID", "c").Return(account, nil)
+	suite.useCase.TransactionGateway = tm
+	suite.useCase.AccountGateway = am
+
+	output, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: since})
+	suite.Nil(err)
+	suite.Equal(4, output.Events)
+	suite.Len(suite.recorder.events, 4)
+
+	deposit := suite.recorder.events[0].(*event.DepositReceived)
+	suite.Equal(event.DepositReceivedPayload{ID: "d1", AccountID: "c", SettlementAccountID: "s", Amount: 50, Balance: 50, SettlementBalance: -50}, deposit.Payload)
+	suite.Equal(deposit.ID, suite.recorder.events[1].(*event.BalanceUpdated).CausationID)
+	withdrawal := suite.recorder.events[2].(*event.WithdrawalCompleted)
+	suite.Equal(event.WithdrawalCompletedPayload{ID: "w1", AccountID: "c", SettlementAccountID: "s", Amount: 30, Balance: 20, SettlementBalance: -20}, withdrawal.Payload)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute_Reversals() {
+	since := suite.since.Add(2 * time.Hour)
+	tm := &TransactionGatewayMock{}
+	tm.On("FindSince", since).Return([]*entity.Transaction{
+		{ID: "t1", AccountFrom: &entity.Account{ID: "a"}, AccountTo: &entity.Account{ID: "b"}, Amount: 50, CreatedAt: since},
+		{ID: "r1", Type: entity.TransactionReversal, OriginalTransactionID: "t1", AccountFrom: &entity.Account{ID: "b"}, AccountTo: &entity.Account{ID: "a"}, Amount: 20, CreatedAt: since.Add(time.Minute)},
+		{ID: "r2", Type: entity.TransactionReversal, OriginalTransactionID: "t1", AccountFrom: &entity.Account{ID: "b"}, AccountTo: &entity.Account{ID: "a"}, Amount: 30, CreatedAt: since.Add(2 * time.Minute)},
+		{ID: "r3", Type: entity.TransactionReversal, OriginalTransactionID: "t0", AccountFrom: &entity.Account{ID: "b"}, AccountTo: &entity.Account{ID: "a"}, Amount: 10, CreatedAt: since.Add(3 * time.Minute)},
+	}, nil)
+	suite.useCase.TransactionGateway = tm
+
+	_, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: since})
+	suite.Nil(err)
+	suite.Len(suite.recorder.events, 8)
+
+	first := suite.recorder.events[2].(*event.TransactionReversed)
+	suite.Equal("t1", first.AggregateID)
+	suite.Equal(entity.TransactionPartiallyReversed, first.Payload.OriginalStatus)
+	suite.Equal(20.0, first.Payload.OriginalReversedAmount)
+	suite.Equal(entity.TransactionReversed, suite.recorder.events[4].(*event.TransactionReversed).Payload.OriginalStatus)
+	suite.Empty(suite.recorder.events[6].(*event.TransactionReversed).Payload.OriginalStatus)
+}
+
+func (suite *ReplayTransactionsUseCaseTestSuite) TestExecute_CapturedTransfers() {
+	since := suite.since.Add(3 * time.Hour)
+	tm := &TransactionGatewayMock{}
+	tm.On("FindSince", since).Return([]*entity.Transaction{
+		{ID: "t1", AccountFrom: &entity.Account{ID: "a"}, AccountTo: &entity.Account{ID: "b"}, Amount: 10, CreatedAt: since},
+		{ID: "c1", Status: entity.TransactionSettled, AccountFrom: &entity.Account{ID: "a"}, AccountTo: &entity.Account{ID: "b"}, Amount: 30,
+			CreatedAt: since.Add(-time.Hour), CapturedAt: since.Add(time.Minute)},
+	}, nil)
+	suite.useCase.TransactionGateway = tm
+
+	output, err := suite.useCase.Execute(context.Background(), ReplayTransactionsInputDTO{From: since, To: since.Add(time.Minute)})
+	suite.Nil(err)
+	suite.Equal(2, output.Transactions)
+	suite.Len(suite.recorder.events, 4)
+
+	captured := suite.recorder.events[2].(*event.TransactionCaptured)
+	suite.Equal(event.TransactionCapturedName, captured.GetName())
+	suite.Equal(since.Add(time.Minute), captured.GetDateTime())
+	suite.Equal(event.TransactionLifecyclePayload{
+		ID: "c1", Status: entity.TransactionSettled, AccountIDFrom: "a", AccountIDTo: "b", Amount: 30,
+		BalanceAccountIDFrom: 70, AvailableBalanceAccountIDFrom: 70, BalanceAccountIDTo: 130,
+	}, captured.Payload)
+	balanceUpdated := suite.recorder.events[3].(*event.BalanceUpdated)
+	suite.Equal(captured.ID, balanceUpdated.CausationID)
+	suite.Equal(since.Add(time.Minute), balanceUpdated.GetDateTime())
+}
+
+func TestReplayTransactionsUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ReplayTransactionsUseCaseTestSuite))
+}
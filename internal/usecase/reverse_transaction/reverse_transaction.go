@@ -0,0 +1,196 @@
+package reverse_transaction
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+type ReverseTransactionInputDTO struct {
+	TransactionID string `json:"-"`
+	// Amount is the part of the transaction to give back. Zero reverses all
+	// that is left of it.
+	Amount float64 `json:"amount"`
+}
+
+func (input ReverseTransactionInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("transaction_id", input.TransactionID)
+	if input.Amount != 0 && errs.Positive("amount", input.Amount) {
+		errs.Cents("amount", input.Amount)
+	}
+	return errs.Err()
+}
+
+type ReverseTransactionOutputDTO struct {
+	ID                    string  `json:"id"`
+	OriginalTransactionID string  `json:"original_transaction_id"`
+	AccountIDFrom         string  `json:"account_id_from"`
+	AccountIDTo           string  `json:"account_id_to"`
+	Amount                float64 `json:"amount"`
+	// OriginalStatus is the status of the original transaction after the
+	// reversal.
+	OriginalStatus         string  `json:"original_status"`
+	OriginalReversedAmount float64 `json:"original_reversed_amount"`
+}
+
+// ReverseTransactionUseCase gives back all or part of a transaction through a
+// compensating reversal transaction, linked to the original one.
+type ReverseTransactionUseCase struct {
+	Uow             uow.UowInterface
+	EventDispatcher events.EventDispatcherInterface
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+}
+
+func NewReverseTransactionUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface) *ReverseTransactionUseCase {
+	return &ReverseTransactionUseCase{
+		Uow:             Uow,
+		EventDispatcher: eventDispatcher,
+	}
+}
+
+func (uc *ReverseTransactionUseCase) Execute(ctx context.Context, input ReverseTransactionInputDTO) (*ReverseTransactionOutputDTO, error) {
+	output := &ReverseTransactionOutputDTO{}
+	payload := event.TransactionReversedPayload{}
+	balanceUpdated := event.BalanceUpdatedPayload{}
+	var belowZero *event.BalanceBelowZeroPayload
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
+
+		original, err := transactionRepository.FindByID(input.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.Lock(original.AccountFrom.ID, original.AccountTo.ID)
+		if err != nil {
+			return err
+		}
+
+		// The stored transaction only carries the IDs of its accounts.
+		original.AccountFrom, err = accountRepository.FindByID(original.AccountFrom.ID)
+		if err != nil {
+			return err
+		}
+		original.AccountTo, err = accountRepository.FindByID(original.AccountTo.ID)
+		if err != nil {
+			return err
+		}
+
+		previousStatus, previousReversedAmount := original.Status, original.ReversedAmount
+		reversal, err := original.Reverse(input.Amount)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(reversal.AccountFrom)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(reversal.AccountTo)
+		if err != nil {
+			return err
+		}
+
+		err = transactionRepository.Create(reversal)
+		if err != nil {
+			return err
+		}
+
+		err = transactionRepository.UpdateStatus(original, previousStatus, previousReversedAmount)
+		if err != nil {
+			return err
+		}
+
+		output.ID = reversal.ID
+		output.OriginalTransactionID = original.ID
+		output.AccountIDFrom = reversal.AccountFrom.ID
+		output.AccountIDTo = reversal.AccountTo.ID
+		output.Amount = reversal.Amount
+		output.OriginalStatus = original.Status
+		output.OriginalReversedAmount = original.ReversedAmount
+
+		payload = event.TransactionReversedPayload{
+			ID:                     reversal.ID,
+			OriginalTransactionID:  original.ID,
+			AccountIDFrom:          reversal.AccountFrom.ID,
+			AccountIDTo:            reversal.AccountTo.ID,
+			Amount:                 reversal.Amount,
+			BalanceAccountIDFrom:   reversal.AccountFrom.Balance,
+			BalanceAccountIDTo:     reversal.AccountTo.Balance,
+			OriginalStatus:         original.Status,
+			OriginalReversedAmount: original.ReversedAmount,
+		}
+		balanceUpdated = event.BalanceUpdatedPayload{
+			AccountIDFrom:        reversal.AccountFrom.ID,
+			AccountIDTo:          reversal.AccountTo.ID,
+			BalanceAccountIDFrom: reversal.AccountFrom.Balance,
+			BalanceAccountIDTo:   reversal.AccountTo.Balance,
+			VersionAccountIDFrom: reversal.AccountFrom.BalanceVersion,
+			VersionAccountIDTo:   reversal.AccountTo.BalanceVersion,
+		}
+		if reversal.OverdrewAccountFrom() {
+			belowZero = &event.BalanceBelowZeroPayload{
+				AccountID:     reversal.AccountFrom.ID,
+				ClientID:      reversal.AccountFrom.Client.ID,
+				TransactionID: reversal.ID,
+				Balance:       reversal.AccountFrom.Balance,
+				Limit:         reversal.AccountFrom.Limit(),
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactionReversed := event.NewTransactionReversedEvent(ctx, payload)
+	events.DispatchOrLog(uc.EventDispatcher, transactionReversed)
+
+	ctx = events.WithCausationID(ctx, transactionReversed.ID)
+	events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceUpdatedEvent(ctx, output.ID, balanceUpdated))
+
+	if belowZero != nil {
+		events.DispatchOrLog(uc.EventDispatcher, event.NewBalanceBelowZeroEvent(ctx, *belowZero))
+	}
+
+	return output, nil
+}
+
+func (uc *ReverseTransactionUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
+
+func (uc *ReverseTransactionUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
+	if err != nil {
+		panic(err)
+	}
+	transactionRepo, ok := repo.(gateway.TransactionGateway)
+	if !ok {
+		panic("repository is not of type TransactionGateway")
+	}
+	return transactionRepo
+}
@@ -0,0 +1,170 @@
+package reverse_transaction
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type ReverseTransactionUseCaseTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	db           *sql.DB
+	accounts     *database.AccountDB
+	transactions *database.TransactionDB
+	recorder     *EventRecorder
+	useCase      *ReverseTransactionUseCase
+	transaction  *entity.Transaction
+}
+
+func (s *ReverseTransactionUseCaseTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+
+	client, _ := entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	sender := entity.NewAccount(client)
+	sender.Credit(1000)
+	recipient := entity.NewAccount(client)
+	s.transaction, err = entity.NewTransaction(sender, recipient, 100)
+	s.Nil(err)
+	s.accounts = database.NewAccountDB(db)
+	s.transactions = database.NewTransactionDB(db)
+	s.Nil(s.accounts.Save(sender))
+	s.Nil(s.accounts.Save(recipient))
+	s.Nil(s.transactions.Create(s.transaction))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.TransactionReversedName, s.recorder)
+	dispatcher.Register(event.BalanceUpdatedName, s.recorder)
+	s.useCase = NewReverseTransactionUseCase(u, dispatcher)
+}
+
+func (s *ReverseTransactionUseCaseTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *ReverseTransactionUseCaseTestSuite) balanceOf(accountID string) float64 {
+	account, err := s.accounts.FindByID(accountID)
+	s.Nil(err)
+	return account.Balance
+}
+
+func (s *ReverseTransactionUseCaseTestSuite) TestExecute_PartialThenFull() {
+	output, err := s.useCase.Execute(s.ctx, ReverseTransactionInputDTO{TransactionID: s.transaction.ID, Amount: 30})
+	s.Nil(err)
+	s.Equal(s.transaction.ID, output.OriginalTransactionID)
+	s.Equal(s.transaction.AccountTo.ID, output.AccountIDFrom)
+	s.Equal(s.transaction.AccountFrom.ID, output.AccountIDTo)
+	s.Equal(30.0, output.Amount)
+	s.Equal(entity.TransactionPartiallyReversed, output.OriginalStatus)
+	s.Equal(930.0, s.balanceOf(s.transaction.AccountFrom.ID))
+	s.Equal(70.0, s.balanceOf(s.transaction.AccountTo.ID))
+
+	reversal, err := s.transactions.FindByID(output.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionReversal, reversal.Type)
+	s.Equal(s.transaction.ID, reversal.OriginalTransactionID)
+
+	output, err = s.useCase.Execute(s.ctx, ReverseTransactionInputDTO{TransactionID: s.transaction.ID})
+	s.Nil(err)
+	s.Equal(70.0, output.Amount)
+	s.Equal(entity.TransactionReversed, output.OriginalStatus)
+	s.Equal(100.0, output.OriginalReversedAmount)
+	s.Equal(1000.0, s.balanceOf(s.transaction.AccountFrom.ID))
+	s.Equal(0.0, s.balanceOf(s.transaction.AccountTo.ID))
+
+	original, err := s.transactions.FindByID(s.transaction.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionReversed, original.Status)
+	s.Equal(100.0, original.ReversedAmount)
+
+	s.Len(s.recorder.events, 4)
+	reversed := s.recorder.events[2].(*event.TransactionReversed)
+	s.Equal(s.transaction.ID, reversed.AggregateID)
+	s.Equal(output.ID, reversed.Payload.ID)
+	s.Equal(entity.TransactionReversed, reversed.Payload.OriginalStatus)
+	updated := s.recorder.events[3].(*event.BalanceUpdated)
+	s.Equal(reversed.ID, updated.CausationID)
+	s.Equal(0.0, updated.Payload.BalanceAccountIDFrom)
+	s.Equal(1000.0, updated.Payload.BalanceAccountIDTo)
+}
+
+func (s *ReverseTransactionUseCaseTestSuite) TestExecute_ExceedsOriginal() {
+	output, err := s.useCase.Execute(s.ctx, ReverseTransactionInputDTO{TransactionID: s.transaction.ID, Amount: 150})
+	s.ErrorIs(err, entity.ErrReversalExceedsOriginal)
+	s.Nil(output)
+	s.Empty(s.recorder.events)
+	s.Equal(100.0, s.balanceOf(s.transaction.AccountTo.ID))
+}
+
+func (s *ReverseTransactionUseCaseTestSuite) TestExecute_RecipientWithoutFunds() {
+	recipient := s.transaction.AccountTo
+	recipient.Debit(80)
+	s.Nil(s.accounts.UpdateBalance(recipient))
+
+	_, err := s.useCase.Execute(s.ctx, ReverseTransactionInputDTO{TransactionID: s.transaction.ID})
+	s.ErrorIs(err, entity.ErrInsufficientFunds)
+
+	original, err := s.transactions.FindByID(s.transaction.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionSettled, original.Status)
+	s.Equal(20.0, s.balanceOf(recipient.ID))
+}
+
+func (s *ReverseTransactionUseCaseTestSuite) TestExecute_TransactionNotFound() {
+	_, err := s.useCase.Execute(s.ctx, ReverseTransactionInputDTO{TransactionID: "invalid_id"})
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func TestReverseTransactionInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, ReverseTransactionInputDTO{TransactionID: "t"}.Validate())
+	assert.Nil(t, ReverseTransactionInputDTO{TransactionID: "t", Amount: 10}.Validate())
+
+	var errs validation.Errors
+	assert.ErrorAs(t, ReverseTransactionInputDTO{Amount: -1}.Validate(), &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "transaction_id", errs[0].Field)
+	assert.Equal(t, "amount", errs[1].Field)
+
+	assert.ErrorAs(t, ReverseTransactionInputDTO{TransactionID: "t", Amount: 0.004}.Validate(), &errs)
+	assert.Equal(t, "must_be_whole_cents", errs[0].Code)
+}
+
+func TestReverseTransactionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ReverseTransactionUseCaseTestSuite))
+}
@@ -0,0 +1,33 @@
+// Package spending_limits holds the spending limit check of the use cases
+// that send money out of an account.
+package spending_limits
+
+import (
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+)
+
+// Check fails with an *entity.LimitExceededError when sending amount from
+// account at now goes over its daily or monthly spending limits. It must run
+// in the unit of work that sends the amount, with the account locked by
+// AccountGateway.Lock before it was read, so that concurrent sends from the
+// account are counted one after the other.
+func Check(transactions gateway.TransactionGateway, account *entity.Account, amount float64, now time.Time) error {
+	limits := account.EffectiveSpendingLimits()
+	if limits.IsZero() {
+		return nil
+	}
+
+	day, month := entity.SpendingPeriods(now)
+	daily, err := transactions.OutgoingUsage(account.ID, day)
+	if err != nil {
+		return err
+	}
+	monthly, err := transactions.OutgoingUsage(account.ID, month)
+	if err != nil {
+		return err
+	}
+	return limits.Check(amount, daily, monthly)
+}
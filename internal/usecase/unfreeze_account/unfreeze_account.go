@@ -0,0 +1,75 @@
+package unfreeze_account
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+type UnfreezeAccountInputDTO struct {
+	AccountID string `json:"-"`
+	Reason    string `json:"reason"`
+}
+
+func (input UnfreezeAccountInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("account_id", input.AccountID)
+	if errs.Required("reason", input.Reason) {
+		errs.OneOf("reason", input.Reason, entity.StatusReasons)
+	}
+	return errs.Err()
+}
+
+type UnfreezeAccountOutputDTO struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type UnfreezeAccountUseCase struct {
+	AccountGateway  gateway.AccountGateway
+	EventDispatcher events.EventDispatcherInterface
+}
+
+func NewUnfreezeAccountUseCase(accountGateway gateway.AccountGateway, eventDispatcher events.EventDispatcherInterface) *UnfreezeAccountUseCase {
+	return &UnfreezeAccountUseCase{
+		AccountGateway:  accountGateway,
+		EventDispatcher: eventDispatcher,
+	}
+}
+
+func (uc *UnfreezeAccountUseCase) Execute(ctx context.Context, input UnfreezeAccountInputDTO) (*UnfreezeAccountOutputDTO, error) {
+	account, err := uc.AccountGateway.FindByID(input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStatus := account.Status
+	err = account.Unfreeze(input.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.AccountGateway.UpdateStatus(account, previousStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	events.DispatchOrLog(uc.EventDispatcher, event.NewAccountStatusChangedEvent(ctx, event.AccountStatusChangedPayload{
+		AccountID:      account.ID,
+		ClientID:       account.Client.ID,
+		Status:         account.Status,
+		PreviousStatus: previousStatus,
+		Reason:         account.StatusReason,
+	}))
+
+	return &UnfreezeAccountOutputDTO{
+		ID:     account.ID,
+		Status: account.Status,
+		Reason: account.StatusReason,
+	}, nil
+}
@@ -0,0 +1,119 @@
+package unfreeze_account
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	account, _ := args.Get(0).(*entity.Account)
+	return account, args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+func newRecordingDispatcher() (*events.EventDispatcher, *EventRecorder) {
+	recorder := &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.AccountStatusChangedName, recorder)
+	return dispatcher, recorder
+}
+
+func TestUnfreezeAccountUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	assert.Nil(t, account.Freeze(entity.FreezeAll, entity.ReasonFraudSuspected))
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	m.On("UpdateStatus", account, entity.AccountFrozen).Return(nil)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewUnfreezeAccountUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), UnfreezeAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonResolved})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.AccountActive, output.Status)
+	assert.Empty(t, account.FreezeMode)
+	m.AssertExpectations(t)
+
+	assert.Len(t, recorder.events, 1)
+	changed := recorder.events[0].(*event.AccountStatusChanged)
+	assert.Equal(t, entity.AccountFrozen, changed.Payload.PreviousStatus)
+	assert.Equal(t, entity.ReasonResolved, changed.Payload.Reason)
+}
+
+func TestUnfreezeAccountUseCase_Execute_NotFrozen(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewUnfreezeAccountUseCase(m, dispatcher)
+	_, err := uc.Execute(context.Background(), UnfreezeAccountInputDTO{AccountID: account.ID, Reason: entity.ReasonResolved})
+
+	assert.Equal(t, &entity.AccountTransitionError{From: entity.AccountActive, To: entity.AccountActive}, err)
+	assert.Empty(t, recorder.events)
+	m.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestUnfreezeAccountInputDTO_Validate(t *testing.T) {
+	var errs validation.Errors
+	assert.ErrorAs(t, UnfreezeAccountInputDTO{}.Validate(), &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "account_id", errs[0].Field)
+	assert.Equal(t, "reason", errs[1].Field)
+}
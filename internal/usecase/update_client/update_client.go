@@ -0,0 +1,79 @@
+package update_client
+
+import (
+	"context"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+type UpdateClientInputDTO struct {
+	ID    string `json:"-"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func (input UpdateClientInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("id", input.ID)
+	errs.Required("name", input.Name)
+	if errs.Required("email", input.Email) {
+		errs.Email("email", input.Email)
+	}
+	return errs.Err()
+}
+
+type UpdateClientOutputDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type UpdateClientUseCase struct {
+	ClientGateway   gateway.ClientGateway
+	EventDispatcher events.EventDispatcherInterface
+}
+
+func NewUpdateClientUseCase(clientGateway gateway.ClientGateway, eventDispatcher events.EventDispatcherInterface) *UpdateClientUseCase {
+	return &UpdateClientUseCase{
+		ClientGateway:   clientGateway,
+		EventDispatcher: eventDispatcher,
+	}
+}
+
+func (uc *UpdateClientUseCase) Execute(ctx context.Context, input UpdateClientInputDTO) (*UpdateClientOutputDTO, error) {
+	client, err := uc.ClientGateway.Get(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Update(input.Name, input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.ClientGateway.Update(client)
+	if err != nil {
+		return nil, err
+	}
+
+	events.DispatchOrLog(uc.EventDispatcher, event.NewClientUpdatedEvent(ctx, event.ClientUpdatedPayload{
+		ClientID:  client.ID,
+		Name:      client.Name,
+		Email:     client.Email,
+		UpdatedAt: client.UpdatedAt,
+	}))
+
+	return &UpdateClientOutputDTO{
+		ID:        client.ID,
+		Name:      client.Name,
+		Email:     client.Email,
+		CreatedAt: client.CreatedAt,
+		UpdatedAt: client.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package update_client
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type ClientGatewayMock struct {
+	mock.Mock
+}
+
+func (m *ClientGatewayMock) Get(id string) (*entity.Client, error) {
+	args := m.Called(id)
+	client, _ := args.Get(0).(*entity.Client)
+	return client, args.Error(1)
+}
+
+func (m *ClientGatewayMock) Save(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Update(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Delete(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*entity.Client), args.Error(1)
+}
+
+func newRecordingDispatcher() (*events.EventDispatcher, *EventRecorder) {
+	recorder := &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.Register(event.ClientUpdatedName, recorder)
+	return dispatcher, recorder
+}
+
+func TestUpdateClientUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	m := &ClientGatewayMock{}
+	m.On("Get", client.ID).Return(client, nil)
+	m.On("Update", client).Return(nil)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewUpdateClientUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), UpdateClientInputDTO{ID: client.ID, Name: "Jane Doe", Email: "jane@j.com"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jane Doe", output.Name)
+	assert.Equal(t, "jane@j.com", output.Email)
+	assert.Equal(t, client.UpdatedAt, output.UpdatedAt)
+	m.AssertExpectations(t)
+
+	assert.Len(t, recorder.events, 1)
+	updated := recorder.events[0].(*event.ClientUpdated)
+	assert.Equal(t, client.ID, updated.AggregateID)
+	assert.Equal(t, event.ClientUpdatedPayload{
+		ClientID:  client.ID,
+		Name:      "Jane Doe",
+		Email:     "jane@j.com",
+		UpdatedAt: client.UpdatedAt,
+	}, updated.Payload)
+}
+
+func TestUpdateClientUseCase_Execute_ClientNotFound(t *testing.T) {
+	m := &ClientGatewayMock{}
+	m.On("Get", "unknown").Return(nil, sql.ErrNoRows)
+	dispatcher, recorder := newRecordingDispatcher()
+
+	uc := NewUpdateClientUseCase(m, dispatcher)
+	output, err := uc.Execute(context.Background(), UpdateClientInputDTO{ID: "unknown", Name: "Jane Doe", Email: "jane@j.com"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, output)
+	m.AssertNotCalled(t, "Update", mock.Anything)
+	assert.Empty(t, recorder.events)
+}
+
+func TestUpdateClientInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, UpdateClientInputDTO{ID: "c", Name: "Jane Doe", Email: "jane@j.com"}.Validate())
+
+	var errs validation.Errors
+	assert.ErrorAs(t, UpdateClientInputDTO{ID: "c", Email: "jane"}.Validate(), &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "name", errs[0].Field)
+	assert.Equal(t, "email", errs[1].Field)
+}
@@ -0,0 +1,61 @@
+package update_spending_limits
+
+import (
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+// UpdateSpendingLimitsInputDTO replaces the spending limits of an account.
+// Zero limits are unset, falling back to the defaults of the client.
+type UpdateSpendingLimitsInputDTO struct {
+	AccountID string `json:"-"`
+	entity.SpendingLimits
+}
+
+func (input UpdateSpendingLimitsInputDTO) Validate() error {
+	var errs validation.Errors
+	errs.Required("account_id", input.AccountID)
+	errs.NonNegative("daily_amount", input.DailyAmount)
+	errs.NonNegative("daily_count", float64(input.DailyCount))
+	errs.NonNegative("monthly_amount", input.MonthlyAmount)
+	errs.NonNegative("monthly_count", float64(input.MonthlyCount))
+	return errs.Err()
+}
+
+type UpdateSpendingLimitsOutputDTO struct {
+	AccountID      string                `json:"account_id"`
+	SpendingLimits entity.SpendingLimits `json:"spending_limits"`
+	// EffectiveSpendingLimits are SpendingLimits with the unset ones taken
+	// from the client.
+	EffectiveSpendingLimits entity.SpendingLimits `json:"effective_spending_limits"`
+}
+
+type UpdateSpendingLimitsUseCase struct {
+	AccountGateway gateway.AccountGateway
+}
+
+func NewUpdateSpendingLimitsUseCase(accountGateway gateway.AccountGateway) *UpdateSpendingLimitsUseCase {
+	return &UpdateSpendingLimitsUseCase{
+		AccountGateway: accountGateway,
+	}
+}
+
+func (u *UpdateSpendingLimitsUseCase) Execute(input UpdateSpendingLimitsInputDTO) (*UpdateSpendingLimitsOutputDTO, error) {
+	account, err := u.AccountGateway.FindByID(input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	account.SpendingLimits = input.SpendingLimits
+	err = u.AccountGateway.UpdateSpendingLimits(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateSpendingLimitsOutputDTO{
+		AccountID:               account.ID,
+		SpendingLimits:          account.SpendingLimits,
+		EffectiveSpendingLimits: account.EffectiveSpendingLimits(),
+	}, nil
+}
@@ -0,0 +1,99 @@
+package update_spending_limits
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	account, _ := args.Get(0).(*entity.Account)
+	return account, args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+func TestUpdateSpendingLimitsUseCase_Execute(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	client.SpendingLimits = entity.SpendingLimits{DailyAmount: 500, MonthlyCount: 30}
+	account := entity.NewAccount(client)
+	m := &AccountGatewayMock{}
+	m.On("FindByID", account.ID).Return(account, nil)
+	m.On("UpdateSpendingLimits", mock.MatchedBy(func(account *entity.Account) bool {
+		return account.SpendingLimits.DailyAmount == 100 && account.SpendingLimits.DailyCount == 5
+	})).Return(nil)
+
+	uc := NewUpdateSpendingLimitsUseCase(m)
+	output, err := uc.Execute(UpdateSpendingLimitsInputDTO{
+		AccountID:      account.ID,
+		SpendingLimits: entity.SpendingLimits{DailyAmount: 100, DailyCount: 5},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.AccountID)
+	assert.Equal(t, entity.SpendingLimits{DailyAmount: 100, DailyCount: 5}, output.SpendingLimits)
+	assert.Equal(t, entity.SpendingLimits{DailyAmount: 100, DailyCount: 5, MonthlyCount: 30}, output.EffectiveSpendingLimits)
+	m.AssertExpectations(t)
+}
+
+func TestUpdateSpendingLimitsUseCase_Execute_NotFound(t *testing.T) {
+	m := &AccountGatewayMock{}
+	m.On("FindByID", "missing").Return(nil, sql.ErrNoRows)
+
+	uc := NewUpdateSpendingLimitsUseCase(m)
+	output, err := uc.Execute(UpdateSpendingLimitsInputDTO{AccountID: "missing"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, output)
+	m.AssertNotCalled(t, "UpdateSpendingLimits", mock.Anything)
+}
+
+func TestUpdateSpendingLimitsInputDTO_Validate(t *testing.T) {
+	assert.Nil(t, UpdateSpendingLimitsInputDTO{AccountID: "a"}.Validate())
+
+	var errs validation.Errors
+	assert.ErrorAs(t, UpdateSpendingLimitsInputDTO{
+		AccountID:      "a",
+		SpendingLimits: entity.SpendingLimits{DailyAmount: -1, MonthlyCount: -1},
+	}.Validate(), &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "daily_amount", errs[0].Field)
+	assert.Equal(t, "monthly_count", errs[1].Field)
+}
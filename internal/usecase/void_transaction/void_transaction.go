@@ -0,0 +1,128 @@
+package void_transaction
+
+import (
+	"context"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+)
+
+type VoidTransactionInputDTO struct {
+	ID string
+}
+
+type VoidTransactionOutputDTO struct {
+	ID            string  `json:"id"`
+	Status        string  `json:"status"`
+	AccountIDFrom string  `json:"account_id_from"`
+	AccountIDTo   string  `json:"account_id_to"`
+	Amount        float64 `json:"amount"`
+}
+
+// VoidTransactionUseCase cancels an authorized transaction, releasing the
+// funds held on the sender.
+type VoidTransactionUseCase struct {
+	Uow             uow.UowInterface
+	EventDispatcher events.EventDispatcherInterface
+	// EventSourced makes the use case persist accounts through the event
+	// store, registered as "AccountEventStore", instead of "AccountDB".
+	EventSourced bool
+}
+
+func NewVoidTransactionUseCase(Uow uow.UowInterface, eventDispatcher events.EventDispatcherInterface) *VoidTransactionUseCase {
+	return &VoidTransactionUseCase{
+		Uow:             Uow,
+		EventDispatcher: eventDispatcher,
+	}
+}
+
+func (uc *VoidTransactionUseCase) Execute(ctx context.Context, input VoidTransactionInputDTO) (*VoidTransactionOutputDTO, error) {
+	var transaction *entity.Transaction
+	err := uc.Uow.Do(ctx, func(tx *uow.Uow) error {
+		accountRepository := uc.getAccountRepository(ctx, tx)
+		transactionRepository := uc.getTransactionRepository(ctx, tx)
+
+		var err error
+		transaction, err = transactionRepository.FindByID(input.ID)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.Lock(transaction.AccountFrom.ID)
+		if err != nil {
+			return err
+		}
+
+		// Only the sender holds funds; the recipient keeps only its ID.
+		transaction.AccountFrom, err = accountRepository.FindByID(transaction.AccountFrom.ID)
+		if err != nil {
+			return err
+		}
+
+		previousStatus := transaction.Status
+		err = transaction.Void()
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateBalance(transaction.AccountFrom)
+		if err != nil {
+			return err
+		}
+
+		return transactionRepository.UpdateStatus(transaction, previousStatus, transaction.ReversedAmount)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	events.DispatchOrLog(uc.EventDispatcher, event.NewTransactionVoidedEvent(ctx, event.TransactionLifecyclePayload{
+		ID:                            transaction.ID,
+		Status:                        transaction.Status,
+		AccountIDFrom:                 transaction.AccountFrom.ID,
+		AccountIDTo:                   transaction.AccountTo.ID,
+		Amount:                        transaction.Amount,
+		BalanceAccountIDFrom:          transaction.AccountFrom.Balance,
+		AvailableBalanceAccountIDFrom: transaction.AccountFrom.AvailableBalance(),
+	}))
+
+	return &VoidTransactionOutputDTO{
+		ID:            transaction.ID,
+		Status:        transaction.Status,
+		AccountIDFrom: transaction.AccountFrom.ID,
+		AccountIDTo:   transaction.AccountTo.ID,
+		Amount:        transaction.Amount,
+	}, nil
+}
+
+func (uc *VoidTransactionUseCase) getAccountRepository(ctx context.Context, tx *uow.Uow) gateway.AccountGateway {
+	name := "AccountDB"
+	if uc.EventSourced {
+		name = "AccountEventStore"
+	}
+	repo, err := tx.GetRepository(ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	accountRepo, ok := repo.(gateway.AccountGateway)
+	if !ok {
+		panic("repository is not of type AccountGateway")
+	}
+	return accountRepo
+}
+
+func (uc *VoidTransactionUseCase) getTransactionRepository(ctx context.Context, tx *uow.Uow) gateway.TransactionGateway {
+	repo, err := tx.GetRepository(ctx, "TransactionDB")
+	if err != nil {
+		panic(err)
+	}
+	transactionRepo, ok := repo.(gateway.TransactionGateway)
+	if !ok {
+		panic("repository is not of type TransactionGateway")
+	}
+	return transactionRepo
+}
@@ -0,0 +1,111 @@
+package void_transaction
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guimartiins/eda-go/internal/database"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/event"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/guimartiins/eda-go/pkg/uow"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []events.EventInterface
+}
+
+func (r *EventRecorder) Handle(e events.EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+type VoidTransactionUseCaseTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	db           *sql.DB
+	accounts     *database.AccountDB
+	transactions *database.TransactionDB
+	recorder     *EventRecorder
+	useCase      *VoidTransactionUseCase
+	transaction  *entity.Transaction
+}
+
+func (s *VoidTransactionUseCaseTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite3", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), credit_line float DEFAULT 0, daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance float, held_balance float DEFAULT 0, overdraft_limit float DEFAULT 0, status varchar(16) DEFAULT 'active', freeze_mode varchar(16) DEFAULT '', status_reason varchar(32) DEFAULT '', daily_amount_limit float DEFAULT 0, daily_count_limit int DEFAULT 0, monthly_amount_limit float DEFAULT 0, monthly_count_limit int DEFAULT 0, currency char(3) DEFAULT 'BRL', balance_version bigint DEFAULT 0, created_at date)")
+	s.db.Exec("CREATE TABLE transactions (id varchar(255), type varchar(16) DEFAULT 'transfer', status varchar(32) DEFAULT 'settled', account_id_from varchar(255), account_id_to varchar(255), amount float, reversed_amount float DEFAULT 0, original_transaction_id varchar(255), expires_at datetime, captured_at datetime, created_at date)")
+
+	client, _ := entity.NewClient("client1", "client1@email.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	sender := entity.NewAccount(client)
+	sender.Credit(100)
+	recipient := entity.NewAccount(client)
+	s.transaction = entity.NewPendingTransaction(sender, recipient, 60)
+	s.Nil(s.transaction.Authorize(time.Now().Add(time.Hour)))
+	s.accounts = database.NewAccountDB(db)
+	s.transactions = database.NewTransactionDB(db)
+	s.Nil(s.accounts.Save(sender))
+	s.Nil(s.accounts.Save(recipient))
+	s.Nil(s.transactions.Create(s.transaction))
+
+	s.ctx = context.Background()
+	u := uow.NewUow(s.ctx, db)
+	u.Register("AccountDB", func(tx *sql.Tx) interface{} { return database.NewAccountDB(tx) })
+	u.Register("TransactionDB", func(tx *sql.Tx) interface{} { return database.NewTransactionDB(tx) })
+
+	s.recorder = &EventRecorder{}
+	dispatcher := events.NewEventDispatcher()
+	dispatcher.RegisterPattern("*", s.recorder)
+	s.useCase = NewVoidTransactionUseCase(u, dispatcher)
+}
+
+func (s *VoidTransactionUseCaseTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func (s *VoidTransactionUseCaseTestSuite) account(id string) *entity.Account {
+	account, err := s.accounts.FindByID(id)
+	s.Nil(err)
+	return account
+}
+
+func (s *VoidTransactionUseCaseTestSuite) TestExecute() {
+	output, err := s.useCase.Execute(s.ctx, VoidTransactionInputDTO{ID: s.transaction.ID})
+	s.Nil(err)
+	s.Equal(entity.TransactionVoided, output.Status)
+
+	sender := s.account(s.transaction.AccountFrom.ID)
+	s.Equal(100.0, sender.Balance)
+	s.Equal(100.0, sender.AvailableBalance())
+	s.Equal(0.0, s.account(s.transaction.AccountTo.ID).Balance)
+
+	s.Len(s.recorder.events, 1)
+	voided := s.recorder.events[0].(*event.TransactionVoided)
+	s.Equal(event.TransactionVoidedName, voided.GetName())
+	s.Equal(100.0, voided.Payload.AvailableBalanceAccountIDFrom)
+
+	_, err = s.useCase.Execute(s.ctx, VoidTransactionInputDTO{ID: s.transaction.ID})
+	s.ErrorIs(err, entity.ErrInvalidTransition)
+}
+
+func (s *VoidTransactionUseCaseTestSuite) TestExecute_NotFound() {
+	_, err := s.useCase.Execute(s.ctx, VoidTransactionInputDTO{ID: "invalid_id"})
+	s.ErrorIs(err, sql.ErrNoRows)
+}
+
+func TestVoidTransactionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(VoidTransactionUseCaseTestSuite))
+}
@@ -1,41 +1,68 @@
 package web
 
 import (
-	"encoding/json"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/guimartiins/eda-go/internal/usecase/create_account"
+	"github.com/guimartiins/eda-go/internal/usecase/get_account"
+	"github.com/guimartiins/eda-go/internal/usecase/update_spending_limits"
 )
 
 type WebAccountHandler struct {
-	CreateAccountUsecase create_account.CreateAccountUseCase
+	CreateAccountUsecase        create_account.CreateAccountUseCase
+	GetAccountUseCase           get_account.GetAccountUseCase
+	UpdateSpendingLimitsUseCase update_spending_limits.UpdateSpendingLimitsUseCase
 }
 
-func NewWebAccountHandler(createAccountUsecase create_account.CreateAccountUseCase) *WebAccountHandler {
+func NewWebAccountHandler(createAccountUsecase create_account.CreateAccountUseCase, getAccountUseCase get_account.GetAccountUseCase, updateSpendingLimitsUseCase update_spending_limits.UpdateSpendingLimitsUseCase) *WebAccountHandler {
 	return &WebAccountHandler{
-		CreateAccountUsecase: createAccountUsecase,
+		CreateAccountUsecase:        createAccountUsecase,
+		GetAccountUseCase:           getAccountUseCase,
+		UpdateSpendingLimitsUseCase: updateSpendingLimitsUseCase,
 	}
 }
 
 func (h *WebAccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	var dto create_account.CreateAccountInputDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
 		return
 	}
 
 	output, err := h.CreateAccountUsecase.Execute(dto)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		WriteProblem(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(output)
+	WriteJSON(w, r, http.StatusCreated, output)
+}
+
+func (h *WebAccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetAccountUseCase.Execute(get_account.GetAccountInputDTO{ID: chi.URLParam(r, "id")})
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		WriteProblem(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
+
+// UpdateSpendingLimits serves PUT /accounts/{id}/spending-limits, replacing
+// all the spending limits of the account.
+func (h *WebAccountHandler) UpdateSpendingLimits(w http.ResponseWriter, r *http.Request) {
+	dto := update_spending_limits.UpdateSpendingLimitsInputDTO{AccountID: chi.URLParam(r, "id")}
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.UpdateSpendingLimitsUseCase.Execute(dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
 }
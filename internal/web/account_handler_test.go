@@ -0,0 +1,124 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/usecase/create_account"
+	"github.com/guimartiins/eda-go/internal/usecase/get_account"
+	"github.com/guimartiins/eda-go/internal/usecase/update_spending_limits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type AccountGatewayMock struct {
+	mock.Mock
+}
+
+func (m *AccountGatewayMock) Save(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
+	args := m.Called(id)
+	account, _ := args.Get(0).(*entity.Account)
+	return account, args.Error(1)
+}
+
+func (m *AccountGatewayMock) FindByClientID(clientID string) ([]*entity.Account, error) {
+	args := m.Called(clientID)
+	return args.Get(0).([]*entity.Account), args.Error(1)
+}
+
+func (m *AccountGatewayMock) Lock(ids ...string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateBalance(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateSpendingLimits(account *entity.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *AccountGatewayMock) UpdateStatus(account *entity.Account, previousStatus string) error {
+	args := m.Called(account, previousStatus)
+	return args.Error(0)
+}
+
+func newAccountRouter(am *AccountGatewayMock, cm *ClientGatewayMock) http.Handler {
+	handler := NewWebAccountHandler(
+		*create_account.NewCreateAccountUseCase(am, cm),
+		*get_account.NewGetAccountUseCase(am),
+		*update_spending_limits.NewUpdateSpendingLimitsUseCase(am),
+	)
+	router := chi.NewRouter()
+	router.Post("/accounts", handler.CreateAccount)
+	router.Get("/accounts/{id}", handler.GetAccount)
+	router.Put("/accounts/{id}/spending-limits", handler.UpdateSpendingLimits)
+	return router
+}
+
+func TestWebAccountHandler_CreateAccount(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	cm := &ClientGatewayMock{}
+	cm.On("Get", client.ID).Return(client, nil)
+	am := &AccountGatewayMock{}
+	am.On("Save", mock.Anything).Return(nil)
+	router := newAccountRouter(am, cm)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(`{"client_id":"`+client.ID+`"}`)))
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, JSONContentType, recorder.Header().Get("Content-Type"))
+	var output create_account.CreateAccountOutputDTO
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&output))
+	assert.NotEmpty(t, output.ID)
+}
+
+func TestWebAccountHandler_GetAccount(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	account.Credit(100)
+	am := &AccountGatewayMock{}
+	am.On("FindByID", account.ID).Return(account, nil)
+	router := newAccountRouter(am, &ClientGatewayMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/accounts/"+account.ID, nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var output get_account.GetAccountOutputDTO
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&output))
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, 100.0, output.Balance)
+}
+
+func TestWebAccountHandler_UpdateSpendingLimits(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	am := &AccountGatewayMock{}
+	am.On("FindByID", account.ID).Return(account, nil)
+	am.On("UpdateSpendingLimits", account).Return(nil)
+	router := newAccountRouter(am, &ClientGatewayMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/accounts/"+account.ID+"/spending-limits",
+		strings.NewReader(`{"daily_amount":500,"daily_count":10}`)))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var output update_spending_limits.UpdateSpendingLimitsOutputDTO
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&output))
+	assert.Equal(t, entity.SpendingLimits{DailyAmount: 500, DailyCount: 10}, output.SpendingLimits)
+}
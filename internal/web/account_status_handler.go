@@ -0,0 +1,77 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/usecase/close_account"
+	"github.com/guimartiins/eda-go/internal/usecase/freeze_account"
+	"github.com/guimartiins/eda-go/internal/usecase/unfreeze_account"
+)
+
+// WebAccountStatusHandler serves the lifecycle of accounts: freezing,
+// unfreezing and closing them, each for a reason code.
+type WebAccountStatusHandler struct {
+	FreezeAccountUseCase   freeze_account.FreezeAccountUseCase
+	UnfreezeAccountUseCase unfreeze_account.UnfreezeAccountUseCase
+	CloseAccountUseCase    close_account.CloseAccountUseCase
+}
+
+func NewWebAccountStatusHandler(freezeAccountUseCase freeze_account.FreezeAccountUseCase, unfreezeAccountUseCase unfreeze_account.UnfreezeAccountUseCase, closeAccountUseCase close_account.CloseAccountUseCase) *WebAccountStatusHandler {
+	return &WebAccountStatusHandler{
+		FreezeAccountUseCase:   freezeAccountUseCase,
+		UnfreezeAccountUseCase: unfreezeAccountUseCase,
+		CloseAccountUseCase:    closeAccountUseCase,
+	}
+}
+
+// FreezeAccount serves POST /accounts/{id}/freeze.
+func (h *WebAccountStatusHandler) FreezeAccount(w http.ResponseWriter, r *http.Request) {
+	dto := freeze_account.FreezeAccountInputDTO{AccountID: chi.URLParam(r, "id")}
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.FreezeAccountUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
+
+// UnfreezeAccount serves POST /accounts/{id}/unfreeze.
+func (h *WebAccountStatusHandler) UnfreezeAccount(w http.ResponseWriter, r *http.Request) {
+	dto := unfreeze_account.UnfreezeAccountInputDTO{AccountID: chi.URLParam(r, "id")}
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.UnfreezeAccountUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
+
+// CloseAccount serves POST /accounts/{id}/close.
+func (h *WebAccountStatusHandler) CloseAccount(w http.ResponseWriter, r *http.Request) {
+	dto := close_account.CloseAccountInputDTO{AccountID: chi.URLParam(r, "id")}
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.CloseAccountUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
@@ -0,0 +1,91 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/usecase/close_account"
+	"github.com/guimartiins/eda-go/internal/usecase/freeze_account"
+	"github.com/guimartiins/eda-go/internal/usecase/unfreeze_account"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAccountStatusRouter(am *AccountGatewayMock) http.Handler {
+	dispatcher := events.NewEventDispatcher()
+	handler := NewWebAccountStatusHandler(
+		*freeze_account.NewFreezeAccountUseCase(am, dispatcher),
+		*unfreeze_account.NewUnfreezeAccountUseCase(am, dispatcher),
+		*close_account.NewCloseAccountUseCase(am, dispatcher),
+	)
+	router := chi.NewRouter()
+	router.Post("/accounts/{id}/freeze", handler.FreezeAccount)
+	router.Post("/accounts/{id}/unfreeze", handler.UnfreezeAccount)
+	router.Post("/accounts/{id}/close", handler.CloseAccount)
+	return router
+}
+
+func TestWebAccountStatusHandler_FreezeAccount(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	am := &AccountGatewayMock{}
+	am.On("FindByID", account.ID).Return(account, nil)
+	am.On("UpdateStatus", account, entity.AccountActive).Return(nil)
+	router := newAccountStatusRouter(am)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts/"+account.ID+"/freeze",
+		strings.NewReader(`{"mode":"all","reason":"fraud_suspected"}`)))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var output freeze_account.FreezeAccountOutputDTO
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&output))
+	assert.Equal(t, entity.AccountFrozen, output.Status)
+	assert.Equal(t, entity.FreezeAll, output.FreezeMode)
+}
+
+func TestWebAccountStatusHandler_FreezeAccount_InvalidReason(t *testing.T) {
+	router := newAccountStatusRouter(&AccountGatewayMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts/a/freeze", strings.NewReader(`{"reason":"bored"}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"invalid_choice"`)
+}
+
+func TestWebAccountStatusHandler_UnfreezeAccount_InvalidTransition(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	am := &AccountGatewayMock{}
+	am.On("FindByID", account.ID).Return(account, nil)
+	router := newAccountStatusRouter(am)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts/"+account.ID+"/unfreeze", strings.NewReader(`{"reason":"resolved"}`)))
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"invalid_account_transition"`)
+	am.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestWebAccountStatusHandler_CloseAccount_NotEmpty(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	account := entity.NewAccount(client)
+	account.Credit(10)
+	am := &AccountGatewayMock{}
+	am.On("FindByID", account.ID).Return(account, nil)
+	router := newAccountStatusRouter(am)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts/"+account.ID+"/close", strings.NewReader(`{"reason":"customer_request"}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"account_not_empty"`)
+}
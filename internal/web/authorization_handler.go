@@ -0,0 +1,65 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/usecase/authorize_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/capture_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/void_transaction"
+)
+
+// WebAuthorizationHandler serves two-phase transfers: an authorization holds
+// the amount on the sender until it is captured or voided.
+type WebAuthorizationHandler struct {
+	AuthorizeTransactionUseCase authorize_transaction.AuthorizeTransactionUseCase
+	CaptureTransactionUseCase   capture_transaction.CaptureTransactionUseCase
+	VoidTransactionUseCase      void_transaction.VoidTransactionUseCase
+}
+
+func NewWebAuthorizationHandler(authorizeTransactionUseCase authorize_transaction.AuthorizeTransactionUseCase, captureTransactionUseCase capture_transaction.CaptureTransactionUseCase, voidTransactionUseCase void_transaction.VoidTransactionUseCase) *WebAuthorizationHandler {
+	return &WebAuthorizationHandler{
+		AuthorizeTransactionUseCase: authorizeTransactionUseCase,
+		CaptureTransactionUseCase:   captureTransactionUseCase,
+		VoidTransactionUseCase:      voidTransactionUseCase,
+	}
+}
+
+// AuthorizeTransaction serves POST /transactions/authorizations.
+func (h *WebAuthorizationHandler) AuthorizeTransaction(w http.ResponseWriter, r *http.Request) {
+	var dto authorize_transaction.AuthorizeTransactionInputDTO
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.AuthorizeTransactionUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusCreated, output)
+}
+
+// CaptureTransaction serves POST /transactions/{id}/capture.
+func (h *WebAuthorizationHandler) CaptureTransaction(w http.ResponseWriter, r *http.Request) {
+	output, err := h.CaptureTransactionUseCase.Execute(r.Context(), capture_transaction.CaptureTransactionInputDTO{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
+
+// VoidTransaction serves POST /transactions/{id}/void.
+func (h *WebAuthorizationHandler) VoidTransaction(w http.ResponseWriter, r *http.Request) {
+	output, err := h.VoidTransactionUseCase.Execute(r.Context(), void_transaction.VoidTransactionInputDTO{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
@@ -0,0 +1,66 @@
+package web
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/usecase/authorize_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/capture_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/mocks"
+	"github.com/guimartiins/eda-go/internal/usecase/void_transaction"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAuthorizationRouter(uow *mocks.UowMock) http.Handler {
+	dispatcher := events.NewEventDispatcher()
+	handler := NewWebAuthorizationHandler(
+		*authorize_transaction.NewAuthorizeTransactionUseCase(uow, dispatcher),
+		*capture_transaction.NewCaptureTransactionUseCase(uow, dispatcher),
+		*void_transaction.NewVoidTransactionUseCase(uow, dispatcher),
+	)
+	router := chi.NewRouter()
+	router.Post("/transactions/authorizations", handler.AuthorizeTransaction)
+	router.Post("/transactions/{id}/capture", handler.CaptureTransaction)
+	router.Post("/transactions/{id}/void", handler.VoidTransaction)
+	return router
+}
+
+func TestWebAuthorizationHandler_AuthorizeTransaction_Invalid(t *testing.T) {
+	router := newAuthorizationRouter(&mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions/authorizations", strings.NewReader(`{"account_id_from":"a","account_id_to":"a","amount":10}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"same_account"`)
+}
+
+func TestWebAuthorizationHandler_CaptureTransaction_InvalidTransition(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(&entity.TransitionError{From: entity.TransactionVoided, To: entity.TransactionSettled})
+	router := newAuthorizationRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions/t/capture", nil))
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"invalid_transition"`)
+}
+
+func TestWebAuthorizationHandler_VoidTransaction_NotFound(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(sql.ErrNoRows)
+	router := newAuthorizationRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions/t/void", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -1,41 +1,126 @@
 package web
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/guimartiins/eda-go/internal/usecase/create_client"
+	"github.com/guimartiins/eda-go/internal/usecase/delete_client"
+	"github.com/guimartiins/eda-go/internal/usecase/get_client"
+	"github.com/guimartiins/eda-go/internal/usecase/list_client_accounts"
+	"github.com/guimartiins/eda-go/internal/usecase/list_clients"
+	"github.com/guimartiins/eda-go/internal/usecase/update_client"
 )
 
 type WebClientHandler struct {
-	CreateClientUsecase create_client.CreateClientUseCase
+	CreateClientUsecase       create_client.CreateClientUseCase
+	GetClientUseCase          get_client.GetClientUseCase
+	ListClientAccountsUseCase list_client_accounts.ListClientAccountsUseCase
+	UpdateClientUseCase       update_client.UpdateClientUseCase
+	DeleteClientUseCase       delete_client.DeleteClientUseCase
+	ListClientsUseCase        list_clients.ListClientsUseCase
 }
 
-func NewWebClientHandler(createClientUsecase create_client.CreateClientUseCase) *WebClientHandler {
+func NewWebClientHandler(createClientUsecase create_client.CreateClientUseCase, getClientUseCase get_client.GetClientUseCase, listClientAccountsUseCase list_client_accounts.ListClientAccountsUseCase,
+	updateClientUseCase update_client.UpdateClientUseCase, deleteClientUseCase delete_client.DeleteClientUseCase, listClientsUseCase list_clients.ListClientsUseCase) *WebClientHandler {
 	return &WebClientHandler{
-		CreateClientUsecase: createClientUsecase,
+		CreateClientUsecase:       createClientUsecase,
+		GetClientUseCase:          getClientUseCase,
+		ListClientAccountsUseCase: listClientAccountsUseCase,
+		UpdateClientUseCase:       updateClientUseCase,
+		DeleteClientUseCase:       deleteClientUseCase,
+		ListClientsUseCase:        listClientsUseCase,
 	}
 }
 
 func (h *WebClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
 	var dto create_client.CreateClientInputDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
 		return
 	}
 
 	output, err := h.CreateClientUsecase.Execute(dto)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusCreated, output)
+}
+
+func (h *WebClientHandler) GetClient(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetClientUseCase.Execute(get_client.GetClientInputDTO{ID: chi.URLParam(r, "id")})
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
+
+func (h *WebClientHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	output, err := h.ListClientAccountsUseCase.Execute(list_client_accounts.ListClientAccountsInputDTO{ClientID: chi.URLParam(r, "id")})
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteList(w, r, output.Accounts, "")
+}
+
+// ListClients serves GET /clients. The q query parameter searches the name
+// and email of the clients, cursor takes the meta.next_cursor of the previous
+// page and limit the page size.
+func (h *WebClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := list_clients.ListClientsInputDTO{
+		Query:  query.Get("q"),
+		Cursor: query.Get("cursor"),
+	}
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if input.Limit, err = strconv.Atoi(value); err != nil {
+			WriteProblem(w, r, fmt.Errorf("%w: invalid limit: %v", ErrMalformedRequest, err))
+			return
+		}
+	}
+
+	output, err := h.ListClientsUseCase.Execute(input)
+	if err != nil {
+		WriteProblem(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(output)
+	WriteList(w, r, output.Clients, output.NextCursor)
+}
+
+// UpdateClient serves PUT /clients/{id}.
+func (h *WebClientHandler) UpdateClient(w http.ResponseWriter, r *http.Request) {
+	dto := update_client.UpdateClientInputDTO{ID: chi.URLParam(r, "id")}
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.UpdateClientUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusOK, output)
+}
+
+// DeleteClient serves DELETE /clients/{id}.
+func (h *WebClientHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
+	err := h.DeleteClientUseCase.Execute(r.Context(), delete_client.DeleteClientInputDTO{ID: chi.URLParam(r, "id")})
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		WriteProblem(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+
+	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,206 @@
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/internal/usecase/create_client"
+	"github.com/guimartiins/eda-go/internal/usecase/delete_client"
+	"github.com/guimartiins/eda-go/internal/usecase/get_client"
+	"github.com/guimartiins/eda-go/internal/usecase/list_client_accounts"
+	"github.com/guimartiins/eda-go/internal/usecase/list_clients"
+	"github.com/guimartiins/eda-go/internal/usecase/mocks"
+	"github.com/guimartiins/eda-go/internal/usecase/update_client"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ClientGatewayMock struct {
+	mock.Mock
+}
+
+func (m *ClientGatewayMock) Get(id string) (*entity.Client, error) {
+	args := m.Called(id)
+	client, _ := args.Get(0).(*entity.Client)
+	return client, args.Error(1)
+}
+
+func (m *ClientGatewayMock) Save(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Update(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) Delete(client *entity.Client) error {
+	args := m.Called(client)
+	return args.Error(0)
+}
+
+func (m *ClientGatewayMock) List(filter gateway.ClientFilter) ([]*entity.Client, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*entity.Client), args.Error(1)
+}
+
+func newClientRouter(cm *ClientGatewayMock, am *AccountGatewayMock, uow *mocks.UowMock) http.Handler {
+	handler := NewWebClientHandler(
+		*create_client.NewCreateClientUseCase(cm),
+		*get_client.NewGetClientUseCase(cm),
+		*list_client_accounts.NewListClientAccountsUseCase(cm, am),
+		*update_client.NewUpdateClientUseCase(cm, events.NewEventDispatcher()),
+		*delete_client.NewDeleteClientUseCase(uow, events.NewEventDispatcher()),
+		*list_clients.NewListClientsUseCase(cm),
+	)
+	router := chi.NewRouter()
+	router.Post("/clients", handler.CreateClient)
+	router.Get("/clients", handler.ListClients)
+	router.Get("/clients/{id}", handler.GetClient)
+	router.Put("/clients/{id}", handler.UpdateClient)
+	router.Delete("/clients/{id}", handler.DeleteClient)
+	router.Get("/clients/{id}/accounts", handler.ListAccounts)
+	return router
+}
+
+func TestWebClientHandler_CreateClient(t *testing.T) {
+	cm := &ClientGatewayMock{}
+	cm.On("Save", mock.Anything).Return(nil)
+	router := newClientRouter(cm, &AccountGatewayMock{}, &mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/clients", strings.NewReader(`{"name":"John Doe","email":"j@j.com"}`)))
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, JSONContentType, recorder.Header().Get("Content-Type"))
+	var output create_client.CreateClientOutputDTO
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&output))
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, "John Doe", output.Name)
+}
+
+func TestWebClientHandler_CreateClient_InvalidBody(t *testing.T) {
+	router := newClientRouter(&ClientGatewayMock{}, &AccountGatewayMock{}, &mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/clients", strings.NewReader(`{"name":`)))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+}
+
+func TestWebClientHandler_CreateClient_ValidationFailed(t *testing.T) {
+	cm := &ClientGatewayMock{}
+	router := newClientRouter(cm, &AccountGatewayMock{}, &mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/clients", strings.NewReader(`{"name":"","email":"john"}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	var problem Problem
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&problem))
+	assert.Equal(t, "validation_failed", problem.Code)
+	assert.Len(t, problem.Errors, 2)
+	assert.Equal(t, "name", problem.Errors[0].Field)
+	assert.Equal(t, "email", problem.Errors[1].Field)
+	cm.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestWebClientHandler_GetClient_NotFound(t *testing.T) {
+	cm := &ClientGatewayMock{}
+	cm.On("Get", "unknown").Return(nil, sql.ErrNoRows)
+	router := newClientRouter(cm, &AccountGatewayMock{}, &mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/clients/unknown", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	var problem Problem
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&problem))
+	assert.Equal(t, "not_found", problem.Code)
+}
+
+func TestWebClientHandler_ListAccounts(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	cm := &ClientGatewayMock{}
+	cm.On("Get", client.ID).Return(client, nil)
+	am := &AccountGatewayMock{}
+	am.On("FindByClientID", client.ID).Return([]*entity.Account(nil), nil)
+	router := newClientRouter(cm, am, &mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/clients/"+client.ID+"/accounts", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"data":[],"meta":{"count":0}}`, recorder.Body.String())
+}
+
+func TestWebClientHandler_UpdateClient(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	cm := &ClientGatewayMock{}
+	cm.On("Get", client.ID).Return(client, nil)
+	cm.On("Update", client).Return(nil)
+	router := newClientRouter(cm, &AccountGatewayMock{}, &mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/clients/"+client.ID, strings.NewReader(`{"name":"Jane Doe","email":"jane@j.com"}`)))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var output update_client.UpdateClientOutputDTO
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&output))
+	assert.Equal(t, client.ID, output.ID)
+	assert.Equal(t, "Jane Doe", output.Name)
+	cm.AssertExpectations(t)
+}
+
+func TestWebClientHandler_ListClients(t *testing.T) {
+	client, _ := entity.NewClient("John Doe", "j@j.com")
+	cm := &ClientGatewayMock{}
+	cm.On("List", gateway.ClientFilter{Query: "john", Limit: 11}).Return([]*entity.Client{client}, nil)
+	router := newClientRouter(cm, &AccountGatewayMock{}, &mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/clients?q=john&limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var envelope ListEnvelope[list_clients.ClientOutputDTO]
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&envelope))
+	assert.Equal(t, 1, envelope.Meta.Count)
+	assert.Equal(t, client.ID, envelope.Data[0].ID)
+}
+
+func TestWebClientHandler_DeleteClient(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(nil)
+	router := newClientRouter(&ClientGatewayMock{}, &AccountGatewayMock{}, uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/clients/7c98685b", nil))
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+	uow.AssertExpectations(t)
+}
+
+func TestWebClientHandler_DeleteClient_ClientHasFunds(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(entity.ErrClientHasFunds)
+	router := newClientRouter(&ClientGatewayMock{}, &AccountGatewayMock{}, uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/clients/7c98685b", nil))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	var problem Problem
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&problem))
+	assert.Equal(t, "client_has_funds", problem.Code)
+}
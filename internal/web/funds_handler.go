@@ -0,0 +1,57 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/usecase/create_deposit"
+	"github.com/guimartiins/eda-go/internal/usecase/create_withdrawal"
+)
+
+// WebFundsHandler moves money in and out of accounts, through the settlement
+// account configured on its use cases.
+type WebFundsHandler struct {
+	CreateDepositUseCase    create_deposit.CreateDepositUseCase
+	CreateWithdrawalUseCase create_withdrawal.CreateWithdrawalUseCase
+}
+
+func NewWebFundsHandler(createDepositUseCase create_deposit.CreateDepositUseCase, createWithdrawalUseCase create_withdrawal.CreateWithdrawalUseCase) *WebFundsHandler {
+	return &WebFundsHandler{
+		CreateDepositUseCase:    createDepositUseCase,
+		CreateWithdrawalUseCase: createWithdrawalUseCase,
+	}
+}
+
+// CreateDeposit serves POST /accounts/{id}/deposits.
+func (h *WebFundsHandler) CreateDeposit(w http.ResponseWriter, r *http.Request) {
+	dto := create_deposit.CreateDepositInputDTO{AccountID: chi.URLParam(r, "id")}
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.CreateDepositUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusCreated, output)
+}
+
+// CreateWithdrawal serves POST /accounts/{id}/withdrawals.
+func (h *WebFundsHandler) CreateWithdrawal(w http.ResponseWriter, r *http.Request) {
+	dto := create_withdrawal.CreateWithdrawalInputDTO{AccountID: chi.URLParam(r, "id")}
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	output, err := h.CreateWithdrawalUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusCreated, output)
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/usecase/create_deposit"
+	"github.com/guimartiins/eda-go/internal/usecase/create_withdrawal"
+	"github.com/guimartiins/eda-go/internal/usecase/mocks"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newFundsRouter(uow *mocks.UowMock) http.Handler {
+	handler := NewWebFundsHandler(
+		*create_deposit.NewCreateDepositUseCase(uow, events.NewEventDispatcher(), "settlement"),
+		*create_withdrawal.NewCreateWithdrawalUseCase(uow, events.NewEventDispatcher(), "settlement"),
+	)
+	router := chi.NewRouter()
+	router.Post("/accounts/{id}/deposits", handler.CreateDeposit)
+	router.Post("/accounts/{id}/withdrawals", handler.CreateWithdrawal)
+	return router
+}
+
+func TestWebFundsHandler_CreateDeposit(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(nil)
+	router := newFundsRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts/a/deposits", strings.NewReader(`{"amount":10}`)))
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, JSONContentType, recorder.Header().Get("Content-Type"))
+}
+
+func TestWebFundsHandler_CreateDeposit_InvalidAmount(t *testing.T) {
+	router := newFundsRouter(&mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts/a/deposits", strings.NewReader(`{"amount":-10}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"field":"amount"`)
+}
+
+func TestWebFundsHandler_CreateWithdrawal_InsufficientFunds(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(entity.ErrInsufficientFunds)
+	router := newFundsRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/accounts/a/withdrawals", strings.NewReader(`{"amount":10}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"insufficient_funds"`)
+}
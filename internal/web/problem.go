@@ -0,0 +1,172 @@
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/gateway"
+	"github.com/guimartiins/eda-go/internal/usecase/create_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/list_account_transactions"
+	"github.com/guimartiins/eda-go/internal/usecase/list_clients"
+	"github.com/guimartiins/eda-go/pkg/validation"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// ErrMalformedRequest is wrapped by the errors of requests whose body, path or
+// query cannot be parsed.
+var ErrMalformedRequest = errors.New("malformed request")
+
+// Problem is an RFC 7807 problem details body. Code is stable and meant for
+// clients to switch on; Title and Detail are for humans.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	// Errors lists every invalid field of a validation_failed problem.
+	Errors []validation.FieldError `json:"errors,omitempty"`
+}
+
+type problemMapping struct {
+	err    error
+	status int
+	code   string
+	title  string
+	// detail is what clients are told about the error, unless it is one of
+	// the typed domain errors of publicDetail.
+	detail string
+}
+
+var internalProblem = problemMapping{status: http.StatusInternalServerError, code: "internal_error", title: "Internal server error"}
+
+// problemRegistry maps errors to problems. The first entry the error matches
+// with errors.Is wins.
+var problemRegistry = []problemMapping{
+	{err: ErrMalformedRequest, status: http.StatusBadRequest, code: "malformed_request", title: "Malformed request",
+		detail: "The request could not be parsed."},
+	{err: validation.ErrInvalid, status: http.StatusUnprocessableEntity, code: "validation_failed", title: "Validation failed",
+		detail: "The request has invalid fields."},
+	{err: list_account_transactions.ErrInvalidCursor, status: http.StatusBadRequest, code: "invalid_cursor", title: "Invalid cursor",
+		detail: "The cursor does not point to a transaction of the account."},
+	{err: list_clients.ErrInvalidCursor, status: http.StatusBadRequest, code: "invalid_cursor", title: "Invalid cursor",
+		detail: "The cursor does not point to a client."},
+	{err: sql.ErrNoRows, status: http.StatusNotFound, code: "not_found", title: "Resource not found",
+		detail: "The requested resource does not exist."},
+	{err: gateway.ErrVersionConflict, status: http.StatusConflict, code: "concurrent_modification", title: "Resource was modified concurrently",
+		detail: "Another request changed the resource first; retry with its current state."},
+	{err: entity.ErrInvalidName, status: http.StatusUnprocessableEntity, code: "invalid_name", title: "Invalid name",
+		detail: "The name must not be empty."},
+	{err: entity.ErrInvalidEmail, status: http.StatusUnprocessableEntity, code: "invalid_email", title: "Invalid email",
+		detail: "The email address is not valid."},
+	{err: entity.ErrClientHasFunds, status: http.StatusUnprocessableEntity, code: "client_has_funds", title: "Client has accounts with a non-zero balance",
+		detail: "The accounts of the client must be emptied first."},
+	{err: entity.ErrInvalidAmount, status: http.StatusUnprocessableEntity, code: "invalid_amount", title: "Invalid amount",
+		detail: "The amount must be greater than zero, in whole cents."},
+	{err: entity.ErrInsufficientFunds, status: http.StatusUnprocessableEntity, code: "insufficient_funds", title: "Insufficient funds",
+		detail: "The account does not have enough funds for the amount."},
+	{err: entity.ErrMissingAccount, status: http.StatusUnprocessableEntity, code: "missing_account", title: "Missing account",
+		detail: "Both accounts of the transaction are required."},
+	{err: entity.ErrSelfTransfer, status: http.StatusUnprocessableEntity, code: "self_transfer", title: "Self transfer",
+		detail: "An account cannot transfer to itself."},
+	{err: entity.ErrAmountBelowMinimum, status: http.StatusUnprocessableEntity, code: "amount_below_minimum", title: "Amount below minimum",
+		detail: "The amount is below the minimum of a transfer."},
+	{err: entity.ErrAmountAboveMaximum, status: http.StatusUnprocessableEntity, code: "amount_above_maximum", title: "Amount above maximum",
+		detail: "The amount is above the maximum of a transfer."},
+	{err: entity.ErrCurrencyPairNotAllowed, status: http.StatusUnprocessableEntity, code: "currency_pair_not_allowed", title: "Currency pair not allowed",
+		detail: "Transfers between the currencies of these accounts are not allowed."},
+	{err: entity.ErrAccountFrozen, status: http.StatusUnprocessableEntity, code: "account_frozen", title: "Account frozen",
+		detail: "One of the accounts is frozen."},
+	{err: entity.ErrAccountClosed, status: http.StatusUnprocessableEntity, code: "account_closed", title: "Account closed",
+		detail: "One of the accounts is closed."},
+	{err: entity.ErrAccountNotEmpty, status: http.StatusUnprocessableEntity, code: "account_not_empty", title: "Account balance is not zero",
+		detail: "The account must have no balance and no held funds."},
+	{err: entity.ErrInvalidAccountTransition, status: http.StatusConflict, code: "invalid_account_transition", title: "Invalid account status transition",
+		detail: "The account cannot move to that status from its current one."},
+	{err: entity.ErrLimitExceeded, status: http.StatusUnprocessableEntity, code: "limit_exceeded", title: "Spending limit exceeded",
+		detail: "The amount exceeds a spending limit of the account."},
+	{err: entity.ErrInvalidTransition, status: http.StatusConflict, code: "invalid_transition", title: "Invalid transaction status transition",
+		detail: "The transaction cannot move to that status from its current one."},
+	{err: entity.ErrAuthorizationExpired, status: http.StatusUnprocessableEntity, code: "authorization_expired", title: "Authorization expired",
+		detail: "The hold of the authorization expired."},
+	{err: entity.ErrTransactionNotReversible, status: http.StatusUnprocessableEntity, code: "transaction_not_reversible", title: "Transaction not reversible",
+		detail: "Only settled and partially reversed transfers can be reversed."},
+	{err: entity.ErrReversalExceedsOriginal, status: http.StatusUnprocessableEntity, code: "reversal_exceeds_original", title: "Reversal exceeds original transaction",
+		detail: "The amount exceeds what is left of the transaction."},
+	{err: create_transaction.ErrIdempotencyKeyReused, status: http.StatusUnprocessableEntity, code: "idempotency_key_reused", title: "Idempotency key reused",
+		detail: "The idempotency key was already used for a different request."},
+	{err: gateway.ErrIdempotencyKeyExists, status: http.StatusConflict, code: "idempotency_key_in_progress", title: "Idempotency key in progress",
+		detail: "Another request with the same idempotency key is being processed; retry later."},
+}
+
+// RegisterProblem maps err, and the errors wrapping it, to a problem. It
+// takes precedence over the mappings registered before it and is meant to
+// be called at startup.
+func RegisterProblem(err error, status int, code string, title string, detail string) {
+	problemRegistry = append([]problemMapping{{err: err, status: status, code: code, title: title, detail: detail}}, problemRegistry...)
+}
+
+// NewProblem returns the problem err maps to. Its detail is the one of the
+// mapping, as the errors may carry internal details such as IDs or driver
+// messages; only the typed domain errors of publicDetail are passed through.
+// Errors missing from the registry become a 500 without detail.
+func NewProblem(err error) Problem {
+	mapping := internalProblem
+	for _, candidate := range problemRegistry {
+		if errors.Is(err, candidate.err) {
+			mapping = candidate
+			break
+		}
+	}
+
+	problem := Problem{
+		Type:   "/problems/" + mapping.code,
+		Title:  mapping.title,
+		Status: mapping.status,
+		Detail: mapping.detail,
+		Code:   mapping.code,
+	}
+	if mapping.status == http.StatusInternalServerError {
+		return problem
+	}
+	if detail, ok := publicDetail(err); ok {
+		problem.Detail = detail
+	}
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		problem.Errors = fieldErrors
+	}
+	return problem
+}
+
+// publicDetail returns the message of the typed domain error err wraps, if
+// any. These errors are built for clients, from their own input and limits.
+func publicDetail(err error) (string, bool) {
+	var amountErr *entity.AmountLimitError
+	var limitErr *entity.LimitExceededError
+	var fieldErrors validation.Errors
+	switch {
+	case errors.As(err, &amountErr):
+		return amountErr.Error(), true
+	case errors.As(err, &limitErr):
+		return limitErr.Error(), true
+	case errors.As(err, &fieldErrors):
+		return fieldErrors.Error(), true
+	}
+	return "", false
+}
+
+// WriteProblem responds to r with the problem err maps to.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := NewProblem(err)
+	problem.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
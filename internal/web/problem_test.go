@@ -0,0 +1,77 @@
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{entity.ErrInvalidName, http.StatusUnprocessableEntity, "invalid_name", "The name must not be empty."},
+		{entity.ErrInvalidEmail, http.StatusUnprocessableEntity, "invalid_email", "The email address is not valid."},
+		{entity.ErrInvalidAmount, http.StatusUnprocessableEntity, "invalid_amount", "The amount must be greater than zero, in whole cents."},
+		{entity.ErrInsufficientFunds, http.StatusUnprocessableEntity, "insufficient_funds", "The account does not have enough funds for the amount."},
+		{entity.ErrSelfTransfer, http.StatusUnprocessableEntity, "self_transfer", "An account cannot transfer to itself."},
+		{&entity.AmountLimitError{Amount: 10, Limit: 5, Err: entity.ErrAmountAboveMaximum}, http.StatusUnprocessableEntity, "amount_above_maximum", "amount is above the maximum: 10.00, limit 5.00"},
+		{fmt.Errorf("checking limits: %w", &entity.LimitExceededError{Limit: entity.LimitDailyCount}), http.StatusUnprocessableEntity, "limit_exceeded", "spending limit exceeded: daily_count, remaining 0.00"},
+		{&entity.CurrencyPairError{From: "BRL", To: "USD"}, http.StatusUnprocessableEntity, "currency_pair_not_allowed", "Transfers between the currencies of these accounts are not allowed."},
+		{fmt.Errorf("finding account 42: %w", sql.ErrNoRows), http.StatusNotFound, "not_found", "The requested resource does not exist."},
+		{fmt.Errorf("%w: unexpected EOF", ErrMalformedRequest), http.StatusBadRequest, "malformed_request", "The request could not be parsed."},
+	}
+	for _, test := range tests {
+		problem := NewProblem(test.err)
+		assert.Equal(t, test.status, problem.Status, test.err.Error())
+		assert.Equal(t, test.code, problem.Code)
+		assert.Equal(t, "/problems/"+test.code, problem.Type)
+		assert.Equal(t, test.detail, problem.Detail)
+	}
+}
+
+func TestNewProblem_HidesUnknownErrors(t *testing.T) {
+	problem := NewProblem(errors.New("dial tcp mysql:3306: connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, "internal_error", problem.Code)
+	assert.Empty(t, problem.Detail)
+}
+
+func TestRegisterProblem(t *testing.T) {
+	registry := problemRegistry
+	defer func() { problemRegistry = registry }()
+
+	errAccountLocked := errors.New("account locked")
+	RegisterProblem(errAccountLocked, http.StatusConflict, "account_locked", "Account locked", "The account is locked.")
+	RegisterProblem(sql.ErrNoRows, http.StatusGone, "gone", "Gone", "")
+
+	assert.Equal(t, http.StatusConflict, NewProblem(errAccountLocked).Status)
+	assert.Equal(t, "The account is locked.", NewProblem(errAccountLocked).Detail)
+	assert.Equal(t, "gone", NewProblem(sql.ErrNoRows).Code)
+}
+
+func TestWriteProblem(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/transactions", nil)
+
+	WriteProblem(recorder, request, entity.ErrInsufficientFunds)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+	var problem Problem
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(&problem))
+	assert.Equal(t, "insufficient_funds", problem.Code)
+	assert.Equal(t, "/transactions", problem.Instance)
+	assert.Equal(t, "The account does not have enough funds for the amount.", problem.Detail)
+}
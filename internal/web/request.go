@@ -0,0 +1,39 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type validator interface {
+	Validate() error
+}
+
+// DecodeRequest decodes the JSON body of r into dto and, when dto has a
+// Validate method, validates it, so that use cases only see well-formed
+// input.
+func DecodeRequest(r *http.Request, dto any) error {
+	if err := json.NewDecoder(r.Body).Decode(dto); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedRequest, err)
+	}
+	return validate(dto)
+}
+
+// DecodeOptionalRequest is DecodeRequest for requests whose body may be
+// empty, however it is sent: then dto is only validated.
+func DecodeOptionalRequest(r *http.Request, dto any) error {
+	if err := json.NewDecoder(r.Body).Decode(dto); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %v", ErrMalformedRequest, err)
+	}
+	return validate(dto)
+}
+
+func validate(dto any) error {
+	if v, ok := dto.(validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+)
+
+const JSONContentType = "application/json"
+
+// ListMeta describes one page of a list response.
+type ListMeta struct {
+	Count      int    `json:"count"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListEnvelope is the body of every list response. Errors use Problem.
+type ListEnvelope[T any] struct {
+	Data []T      `json:"data"`
+	Meta ListMeta `json:"meta"`
+}
+
+// WriteJSON responds with body encoded as JSON. The body is encoded before
+// anything is written, so that an encoding failure still turns into a
+// problem response instead of a truncated body with the wrong status.
+func WriteJSON(w http.ResponseWriter, r *http.Request, status int, body any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", JSONContentType)
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// WriteList responds with items wrapped in a ListEnvelope. An empty page is
+// encoded as an empty array, never null.
+func WriteList[T any](w http.ResponseWriter, r *http.Request, items []T, nextCursor string) {
+	if items == nil {
+		items = []T{}
+	}
+	WriteJSON(w, r, http.StatusOK, ListEnvelope[T]{
+		Data: items,
+		Meta: ListMeta{Count: len(items), NextCursor: nextCursor},
+	})
+}
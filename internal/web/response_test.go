@@ -0,0 +1,36 @@
+package web
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJSON(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteJSON(recorder, httptest.NewRequest(http.MethodPost, "/things", nil), http.StatusCreated, map[string]string{"id": "1"})
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, JSONContentType, recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"id":"1"}`, recorder.Body.String())
+}
+
+func TestWriteJSON_EncodeFailure(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteJSON(recorder, httptest.NewRequest(http.MethodGet, "/things", nil), http.StatusOK, math.NaN())
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `"code":"internal_error"`)
+}
+
+func TestWriteList(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteList(recorder, httptest.NewRequest(http.MethodGet, "/things", nil), []string{"a", "b"}, "b")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"data":["a","b"],"meta":{"count":2,"next_cursor":"b"}}`, recorder.Body.String())
+}
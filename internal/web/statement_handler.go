@@ -0,0 +1,60 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/usecase/list_account_transactions"
+)
+
+type WebStatementHandler struct {
+	ListAccountTransactionsUseCase list_account_transactions.ListAccountTransactionsUseCase
+}
+
+func NewWebStatementHandler(listAccountTransactionsUseCase list_account_transactions.ListAccountTransactionsUseCase) *WebStatementHandler {
+	return &WebStatementHandler{
+		ListAccountTransactionsUseCase: listAccountTransactionsUseCase,
+	}
+}
+
+// ListAccountTransactions serves GET /accounts/{id}/transactions. The from and
+// to query parameters take RFC 3339 times, cursor takes the meta.next_cursor of
+// the previous page and limit the page size.
+func (h *WebStatementHandler) ListAccountTransactions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := list_account_transactions.ListAccountTransactionsInputDTO{
+		AccountID: chi.URLParam(r, "id"),
+		Cursor:    query.Get("cursor"),
+	}
+
+	var err error
+	if value := query.Get("from"); value != "" {
+		if input.From, err = time.Parse(time.RFC3339, value); err != nil {
+			WriteProblem(w, r, fmt.Errorf("%w: invalid from: %v", ErrMalformedRequest, err))
+			return
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if input.To, err = time.Parse(time.RFC3339, value); err != nil {
+			WriteProblem(w, r, fmt.Errorf("%w: invalid to: %v", ErrMalformedRequest, err))
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if input.Limit, err = strconv.Atoi(value); err != nil {
+			WriteProblem(w, r, fmt.Errorf("%w: invalid limit: %v", ErrMalformedRequest, err))
+			return
+		}
+	}
+
+	output, err := h.ListAccountTransactionsUseCase.Execute(input)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteList(w, r, output.Transactions, output.NextCursor)
+}
@@ -1,47 +1,80 @@
 package web
 
 import (
-	"encoding/json"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/guimartiins/eda-go/internal/usecase/create_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/get_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/reverse_transaction"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
 )
 
 type WebTransactionHandler struct {
-	CreateTransactionUsecase create_transaction.CreateTransactionUseCase
+	CreateTransactionUsecase  create_transaction.CreateTransactionUseCase
+	GetTransactionUseCase     get_transaction.GetTransactionUseCase
+	ReverseTransactionUseCase reverse_transaction.ReverseTransactionUseCase
 }
 
-func NewWebTransactionHandler(createTransactionUsecase create_transaction.CreateTransactionUseCase) *WebTransactionHandler {
+func NewWebTransactionHandler(createTransactionUsecase create_transaction.CreateTransactionUseCase, getTransactionUseCase get_transaction.GetTransactionUseCase, reverseTransactionUseCase reverse_transaction.ReverseTransactionUseCase) *WebTransactionHandler {
 	return &WebTransactionHandler{
-		CreateTransactionUsecase: createTransactionUsecase,
+		CreateTransactionUsecase:  createTransactionUsecase,
+		GetTransactionUseCase:     getTransactionUseCase,
+		ReverseTransactionUseCase: reverseTransactionUseCase,
 	}
 }
 
 func (h *WebTransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 	var dto create_transaction.CreateTransactionInputDTO
-	err := json.NewDecoder(r.Body).Decode(&dto)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(err.Error()))
+	if err := DecodeRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
 		return
 	}
 
+	dto.IdempotencyKey = r.Header.Get(IdempotencyKeyHeader)
 	ctx := r.Context()
 
 	output, err := h.CreateTransactionUsecase.Execute(ctx, dto)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(err.Error()))
+		WriteProblem(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(output)
+	if output.Replayed {
+		w.Header().Set(IdempotentReplayedHeader, "true")
+	}
+	WriteJSON(w, r, http.StatusCreated, output)
+}
+
+func (h *WebTransactionHandler) GetTransaction(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetTransactionUseCase.Execute(get_transaction.GetTransactionInputDTO{ID: chi.URLParam(r, "id")})
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(err.Error()))
+		WriteProblem(w, r, err)
+		return
+	}
 
+	WriteJSON(w, r, http.StatusOK, output)
+}
+
+// ReverseTransaction serves POST /transactions/{id}/reversal. The body is
+// optional: without an amount, all that is left of the transaction is given
+// back.
+func (h *WebTransactionHandler) ReverseTransaction(w http.ResponseWriter, r *http.Request) {
+	dto := reverse_transaction.ReverseTransactionInputDTO{TransactionID: chi.URLParam(r, "id")}
+	if err := DecodeOptionalRequest(r, &dto); err != nil {
+		WriteProblem(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+
+	output, err := h.ReverseTransactionUseCase.Execute(r.Context(), dto)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return
+	}
+
+	WriteJSON(w, r, http.StatusCreated, output)
 }
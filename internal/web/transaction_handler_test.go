@@ -0,0 +1,125 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/guimartiins/eda-go/internal/entity"
+	"github.com/guimartiins/eda-go/internal/usecase/create_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/get_transaction"
+	"github.com/guimartiins/eda-go/internal/usecase/mocks"
+	"github.com/guimartiins/eda-go/internal/usecase/reverse_transaction"
+	"github.com/guimartiins/eda-go/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTransactionRouter(uow *mocks.UowMock) http.Handler {
+	handler := NewWebTransactionHandler(
+		*create_transaction.NewCreateTransactionUseCase(uow, events.NewEventDispatcher()),
+		get_transaction.GetTransactionUseCase{},
+		*reverse_transaction.NewReverseTransactionUseCase(uow, events.NewEventDispatcher()),
+	)
+	router := chi.NewRouter()
+	router.Post("/transactions", handler.CreateTransaction)
+	router.Post("/transactions/{id}/reversal", handler.ReverseTransaction)
+	return router
+}
+
+func TestWebTransactionHandler_CreateTransaction(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(nil)
+	router := newTransactionRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{"account_id_from":"a","account_id_to":"b","amount":10}`)))
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, JSONContentType, recorder.Header().Get("Content-Type"))
+}
+
+func TestWebTransactionHandler_CreateTransaction_IdempotencyKeyReused(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(create_transaction.ErrIdempotencyKeyReused)
+	router := newTransactionRouter(uow)
+
+	request := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{"account_id_from":"a","account_id_to":"b","amount":10}`))
+	request.Header.Set(IdempotencyKeyHeader, "key")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"idempotency_key_reused"`)
+}
+
+func TestWebTransactionHandler_CreateTransaction_InsufficientFunds(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(entity.ErrInsufficientFunds)
+	router := newTransactionRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{"account_id_from":"a","account_id_to":"b","amount":10}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `"code":"insufficient_funds"`)
+}
+
+func TestWebTransactionHandler_ReverseTransaction_WithoutBody(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(nil)
+	router := newTransactionRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions/t/reversal", nil))
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+}
+
+func TestWebTransactionHandler_ReverseTransaction_ChunkedEmptyBody(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(nil)
+	router := newTransactionRouter(uow)
+
+	request := httptest.NewRequest(http.MethodPost, "/transactions/t/reversal", strings.NewReader(""))
+	request.ContentLength = -1
+	request.TransferEncoding = []string{"chunked"}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+}
+
+func TestWebTransactionHandler_ReverseTransaction_MalformedBody(t *testing.T) {
+	router := newTransactionRouter(&mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions/t/reversal", strings.NewReader(`{"amount":`)))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestWebTransactionHandler_ReverseTransaction_ExceedsOriginal(t *testing.T) {
+	uow := &mocks.UowMock{}
+	uow.On("Do", mock.Anything).Return(&entity.ReversalAmountError{Amount: 150, Remaining: 100})
+	router := newTransactionRouter(uow)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions/t/reversal", strings.NewReader(`{"amount":150}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"code":"reversal_exceeds_original"`)
+}
+
+func TestWebTransactionHandler_ReverseTransaction_NegativeAmount(t *testing.T) {
+	router := newTransactionRouter(&mocks.UowMock{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transactions/t/reversal", strings.NewReader(`{"amount":-1}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"field":"amount"`)
+}
@@ -0,0 +1,25 @@
+package webserver
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/guimartiins/eda-go/pkg/events"
+)
+
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// CorrelationID propagates the X-Correlation-ID header, or a new ID when the
+// request has none, to the events raised while serving the request.
+func CorrelationID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID := r.Header.Get(CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = uuid.New().String()
+		}
+
+		w.Header().Set(CorrelationIDHeader, correlationID)
+		ctx := events.WithCorrelationID(r.Context(), correlationID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
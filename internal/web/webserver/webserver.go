@@ -1,43 +1,107 @@
 package webserver
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sync"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 )
 
+type route struct {
+	method  string
+	path    string
+	handler http.HandlerFunc
+}
+
+// RouteGroup collects routes sharing a path prefix and a middleware chain.
+// Routes and nested groups are mounted in the order they were added; paths
+// take chi patterns, such as "/accounts/{id}".
+type RouteGroup struct {
+	Prefix      string
+	middlewares []func(http.Handler) http.Handler
+	routes      []route
+	groups      []*RouteGroup
+}
+
+// Use appends middlewares run for every route of the group and of its nested
+// groups.
+func (g *RouteGroup) Use(middlewares ...func(http.Handler) http.Handler) {
+	g.middlewares = append(g.middlewares, middlewares...)
+}
+
+func (g *RouteGroup) AddHandler(method string, path string, handler http.HandlerFunc) {
+	g.routes = append(g.routes, route{method: method, path: path, handler: handler})
+}
+
+// Group returns a nested group under prefix. An empty prefix groups routes
+// only to give them their own middlewares.
+func (g *RouteGroup) Group(prefix string, middlewares ...func(http.Handler) http.Handler) *RouteGroup {
+	group := &RouteGroup{Prefix: prefix, middlewares: middlewares}
+	g.groups = append(g.groups, group)
+	return group
+}
+
+func (g *RouteGroup) mount(r chi.Router) {
+	r.Use(g.middlewares...)
+	for _, route := range g.routes {
+		r.Method(route.method, route.path, route.handler)
+	}
+	for _, group := range g.groups {
+		if group.Prefix == "" {
+			r.Group(group.mount)
+			continue
+		}
+		r.Route(group.Prefix, group.mount)
+	}
+}
+
 type WebServer struct {
+	*RouteGroup
 	Router        chi.Router
-	Handlers      map[string]http.HandlerFunc
 	WebServerPort string
+	mountOnce     sync.Once
+	server        *http.Server
 }
 
 func NewWebServer(webServerPort string) *WebServer {
+	root := &RouteGroup{}
+	root.Use(middleware.Logger, CorrelationID)
+
 	return &WebServer{
+		RouteGroup:    root,
 		Router:        chi.NewRouter(),
-		Handlers:      make(map[string]http.HandlerFunc),
 		WebServerPort: webServerPort,
+		server:        &http.Server{Addr: ":" + webServerPort},
 	}
 }
 
-func (s *WebServer) AddHandler(path string, handler http.HandlerFunc) {
-	s.Handlers[path] = handler
+// Handler mounts the routes on the router, the first time it is called, and
+// returns it. Routes added afterwards are ignored.
+func (s *WebServer) Handler() http.Handler {
+	s.mountOnce.Do(func() {
+		s.RouteGroup.mount(s.Router)
+	})
+	return s.Router
 }
 
 func (s *WebServer) Start() {
-	s.Router.Use(middleware.Logger)
-
-	for path, handler := range s.Handlers {
-		s.Router.Post(path, handler)
-	}
+	handler := s.Handler()
 
 	// Add startup message
 	println("Server is running on port", s.WebServerPort)
 
-	// Add ":" prefix to port and handle error
-	err := http.ListenAndServe(":"+s.WebServerPort, s.Router)
-	if err != nil {
+	s.server.Handler = handler
+	err := s.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		panic(err)
 	}
 }
+
+// Shutdown stops accepting requests and waits until the ones being served
+// are done or ctx is.
+func (s *WebServer) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
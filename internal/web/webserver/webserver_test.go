@@ -0,0 +1,77 @@
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func respond(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}
+}
+
+func tag(value string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Tag", value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func serve(handler http.Handler, method string, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	return recorder
+}
+
+func TestWebServer_RoutesByMethod(t *testing.T) {
+	server := NewWebServer("0")
+	server.AddHandler(http.MethodPost, "/things", respond("created"))
+	server.AddHandler(http.MethodGet, "/things", respond("listed"))
+	handler := server.Handler()
+
+	assert.Equal(t, "created", serve(handler, http.MethodPost, "/things").Body.String())
+	assert.Equal(t, "listed", serve(handler, http.MethodGet, "/things").Body.String())
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(handler, http.MethodDelete, "/things").Code)
+}
+
+func TestWebServer_Groups(t *testing.T) {
+	server := NewWebServer("0")
+	server.AddHandler(http.MethodGet, "/health", respond("ok"))
+	v1 := server.Group("/v1", tag("v1"))
+	accounts := v1.Group("/accounts", tag("accounts"))
+	accounts.AddHandler(http.MethodPost, "/", respond("created"))
+	accounts.AddHandler(http.MethodGet, "/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(chi.URLParam(r, "id")))
+	})
+	handler := server.Handler()
+
+	response := serve(handler, http.MethodPost, "/v1/accounts")
+	assert.Equal(t, "created", response.Body.String())
+	assert.Equal(t, []string{"v1", "accounts"}, response.Header().Values("X-Tag"))
+
+	response = serve(handler, http.MethodGet, "/v1/accounts/123")
+	assert.Equal(t, "123", response.Body.String())
+
+	response = serve(handler, http.MethodGet, "/health")
+	assert.Equal(t, "ok", response.Body.String())
+	assert.Empty(t, response.Header().Values("X-Tag"))
+	assert.NotEmpty(t, response.Header().Get(CorrelationIDHeader))
+}
+
+func TestWebServer_GroupWithoutPrefix(t *testing.T) {
+	server := NewWebServer("0")
+	server.AddHandler(http.MethodGet, "/public", respond("public"))
+	private := server.Group("", tag("private"))
+	private.AddHandler(http.MethodGet, "/private", respond("private"))
+	handler := server.Handler()
+
+	assert.Empty(t, serve(handler, http.MethodGet, "/public").Header().Values("X-Tag"))
+	assert.Equal(t, []string{"private"}, serve(handler, http.MethodGet, "/private").Header().Values("X-Tag"))
+}
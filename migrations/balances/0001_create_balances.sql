@@ -0,0 +1,6 @@
+CREATE TABLE balances (
+    account_id varchar(255) NOT NULL PRIMARY KEY,
+    balance double NOT NULL,
+    updated_at datetime(6) NOT NULL,
+    last_event_id varchar(255) NOT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE balances ADD COLUMN version bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,18 @@
+CREATE TABLE events (
+    id varchar(255) NOT NULL PRIMARY KEY,
+    aggregate_id varchar(255) NOT NULL,
+    aggregate_type varchar(255) NOT NULL,
+    version int NOT NULL,
+    name varchar(255) NOT NULL,
+    payload json NOT NULL,
+    occurred_at datetime(6) NOT NULL,
+    UNIQUE KEY events_aggregate_version (aggregate_id, version)
+);
+
+CREATE TABLE snapshots (
+    aggregate_id varchar(255) NOT NULL PRIMARY KEY,
+    aggregate_type varchar(255) NOT NULL,
+    version int NOT NULL,
+    state json NOT NULL,
+    created_at datetime(6) NOT NULL
+);
@@ -0,0 +1,12 @@
+CREATE TABLE account_statements (
+    id varchar(255) NOT NULL PRIMARY KEY,
+    account_id varchar(255) NOT NULL,
+    transaction_id varchar(255) NOT NULL,
+    direction varchar(16) NOT NULL,
+    counterparty_account_id varchar(255) NOT NULL,
+    amount float NOT NULL,
+    balance float NOT NULL,
+    created_at datetime(6) NOT NULL,
+    UNIQUE KEY account_statements_account_transaction (account_id, transaction_id),
+    KEY account_statements_account_created (account_id, created_at, id)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE idempotency_keys (
+    idempotency_key varchar(255) NOT NULL PRIMARY KEY,
+    request_hash char(64) NOT NULL,
+    response blob NOT NULL,
+    created_at datetime(6) NOT NULL,
+    expires_at datetime(6) NOT NULL,
+    KEY idempotency_keys_expires_at (expires_at)
+);
@@ -0,0 +1 @@
+ALTER TABLE transactions ADD COLUMN type varchar(16) NOT NULL DEFAULT 'transfer' AFTER id;
@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    ADD COLUMN status varchar(32) NOT NULL DEFAULT 'settled' AFTER type,
+    ADD COLUMN reversed_amount float NOT NULL DEFAULT 0 AFTER amount,
+    ADD COLUMN original_transaction_id varchar(255) NULL AFTER reversed_amount,
+    ADD INDEX idx_transactions_original_transaction_id (original_transaction_id);
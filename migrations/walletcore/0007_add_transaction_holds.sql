@@ -0,0 +1,5 @@
+ALTER TABLE accounts ADD COLUMN held_balance float NOT NULL DEFAULT 0 AFTER balance;
+
+ALTER TABLE transactions
+    ADD COLUMN expires_at datetime(6) NULL AFTER original_transaction_id,
+    ADD INDEX idx_transactions_status_expires_at (status, expires_at);
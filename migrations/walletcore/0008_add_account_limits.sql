@@ -0,0 +1,3 @@
+ALTER TABLE accounts ADD COLUMN overdraft_limit float NOT NULL DEFAULT 0 AFTER held_balance;
+
+ALTER TABLE clients ADD COLUMN credit_line float NOT NULL DEFAULT 0 AFTER email;
@@ -0,0 +1,13 @@
+ALTER TABLE accounts
+    ADD COLUMN daily_amount_limit float NOT NULL DEFAULT 0 AFTER overdraft_limit,
+    ADD COLUMN daily_count_limit int NOT NULL DEFAULT 0 AFTER daily_amount_limit,
+    ADD COLUMN monthly_amount_limit float NOT NULL DEFAULT 0 AFTER daily_count_limit,
+    ADD COLUMN monthly_count_limit int NOT NULL DEFAULT 0 AFTER monthly_amount_limit;
+
+ALTER TABLE clients
+    ADD COLUMN daily_amount_limit float NOT NULL DEFAULT 0 AFTER credit_line,
+    ADD COLUMN daily_count_limit int NOT NULL DEFAULT 0 AFTER daily_amount_limit,
+    ADD COLUMN monthly_amount_limit float NOT NULL DEFAULT 0 AFTER daily_count_limit,
+    ADD COLUMN monthly_count_limit int NOT NULL DEFAULT 0 AFTER monthly_amount_limit;
+
+ALTER TABLE transactions ADD INDEX idx_transactions_account_from_created_at (account_id_from, created_at);
@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+    ADD COLUMN status varchar(16) NOT NULL DEFAULT 'active' AFTER overdraft_limit,
+    ADD COLUMN freeze_mode varchar(16) NOT NULL DEFAULT '' AFTER status,
+    ADD COLUMN status_reason varchar(32) NOT NULL DEFAULT '' AFTER freeze_mode;
@@ -0,0 +1,3 @@
+ALTER TABLE clients
+    ADD COLUMN deleted_at datetime NULL AFTER updated_at,
+    ADD INDEX idx_clients_created_at (created_at, id);
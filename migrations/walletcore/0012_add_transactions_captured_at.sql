@@ -0,0 +1,2 @@
+ALTER TABLE transactions
+    ADD COLUMN captured_at datetime(6) NULL AFTER expires_at;
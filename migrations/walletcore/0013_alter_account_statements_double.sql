@@ -0,0 +1,3 @@
+ALTER TABLE account_statements
+    MODIFY COLUMN amount double NOT NULL,
+    MODIFY COLUMN balance double NOT NULL;
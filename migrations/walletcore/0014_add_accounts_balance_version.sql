@@ -0,0 +1 @@
+ALTER TABLE accounts ADD COLUMN balance_version bigint NOT NULL DEFAULT 0 AFTER held_balance;
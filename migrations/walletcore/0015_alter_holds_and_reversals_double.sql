@@ -0,0 +1,3 @@
+ALTER TABLE transactions MODIFY COLUMN reversed_amount double NOT NULL DEFAULT 0;
+
+ALTER TABLE accounts MODIFY COLUMN held_balance double NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+ALTER TABLE accounts
+    MODIFY COLUMN overdraft_limit double NOT NULL DEFAULT 0,
+    MODIFY COLUMN daily_amount_limit double NOT NULL DEFAULT 0,
+    MODIFY COLUMN monthly_amount_limit double NOT NULL DEFAULT 0;
+
+ALTER TABLE clients
+    MODIFY COLUMN credit_line double NOT NULL DEFAULT 0,
+    MODIFY COLUMN daily_amount_limit double NOT NULL DEFAULT 0,
+    MODIFY COLUMN monthly_amount_limit double NOT NULL DEFAULT 0;
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Envelope is the metadata every event carries. Events embed it so that it
+// is published next to their payload in a stable JSON shape.
+type Envelope struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Version       int       `json:"version"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	AggregateID   string    `json:"aggregate_id"`
+	AggregateType string    `json:"aggregate_type"`
+	CorrelationID string    `json:"correlation_id"`
+	CausationID   string    `json:"causation_id,omitempty"`
+}
+
+// NewEnvelope stamps a new event occurrence. The correlation and causation
+// IDs are taken from ctx; an event without a correlation ID starts a new
+// correlation with its own ID.
+func NewEnvelope(ctx context.Context, name string, version int, aggregateType string, aggregateID string) Envelope {
+	id := uuid.New().String()
+	correlationID := CorrelationID(ctx)
+	if correlationID == "" {
+		correlationID = id
+	}
+
+	return Envelope{
+		ID:            id,
+		Name:          name,
+		Version:       version,
+		OccurredAt:    time.Now().UTC(),
+		AggregateID:   aggregateID,
+		AggregateType: aggregateType,
+		CorrelationID: correlationID,
+		CausationID:   CausationID(ctx),
+	}
+}
+
+func (e Envelope) GetName() string {
+	return e.Name
+}
+
+func (e Envelope) GetDateTime() time.Time {
+	return e.OccurredAt
+}
+
+func (e Envelope) GetEnvelope() Envelope {
+	return e
+}
+
+type contextKey string
+
+const (
+	correlationIDKey contextKey = "correlation_id"
+	causationIDKey   contextKey = "causation_id"
+)
+
+// WithCorrelationID returns a context whose events share correlationID.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey).(string)
+	return id
+}
+
+// WithCausationID returns a context whose events are marked as caused by the
+// event or command identified by causationID.
+func WithCausationID(ctx context.Context, causationID string) context.Context {
+	return context.WithValue(ctx, causationIDKey, causationID)
+}
+
+func CausationID(ctx context.Context) string {
+	id, _ := ctx.Value(causationIDKey).(string)
+	return id
+}
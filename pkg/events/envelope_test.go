@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type EnvelopedTestEvent struct {
+	Envelope
+	Payload map[string]string `json:"payload"`
+}
+
+func (e *EnvelopedTestEvent) GetPayload() any {
+	return e.Payload
+}
+
+func TestNewEnvelope(t *testing.T) {
+	envelope := NewEnvelope(context.Background(), "test", 2, "Account", "account-1")
+
+	assert.NotEmpty(t, envelope.ID)
+	assert.Equal(t, "test", envelope.GetName())
+	assert.Equal(t, 2, envelope.Version)
+	assert.Equal(t, "Account", envelope.AggregateType)
+	assert.Equal(t, "account-1", envelope.AggregateID)
+	assert.Equal(t, envelope.ID, envelope.CorrelationID)
+	assert.Empty(t, envelope.CausationID)
+
+	occurredAt := envelope.GetDateTime()
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, occurredAt, envelope.GetDateTime())
+}
+
+func TestNewEnvelope_WithCorrelationAndCausation(t *testing.T) {
+	ctx := WithCorrelationID(context.Background(), "request-1")
+	ctx = WithCausationID(ctx, "event-1")
+
+	envelope := NewEnvelope(ctx, "test", 1, "Account", "account-1")
+	assert.Equal(t, "request-1", envelope.CorrelationID)
+	assert.Equal(t, "event-1", envelope.CausationID)
+}
+
+func TestEnvelope_JSON(t *testing.T) {
+	event := &EnvelopedTestEvent{
+		Envelope: NewEnvelope(context.Background(), "test", 1, "Account", "account-1"),
+		Payload:  map[string]string{"key": "value"},
+	}
+
+	data, err := json.Marshal(event)
+	assert.Nil(t, err)
+
+	var decoded map[string]any
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.ElementsMatch(t,
+		[]string{"id", "name", "version", "occurred_at", "aggregate_id", "aggregate_type", "correlation_id", "payload"},
+		keys(decoded),
+	)
+	assert.Equal(t, "account-1", decoded["aggregate_id"])
+}
+
+func keys(m map[string]any) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
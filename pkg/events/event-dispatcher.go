@@ -1,25 +1,165 @@
 package events
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 	"slices"
+	"strings"
 	"sync"
 )
 
 var ErrHandlerAlreadyRegistered = errors.New("handler already registered")
 var ErrorCleanDispatcher = errors.New("Error")
+var ErrQueueFull = errors.New("event queue is full")
+var ErrDispatcherClosed = errors.New("event dispatcher is shut down")
+
+// OverflowPolicy decides what an asynchronous Dispatch does when the queue
+// of the event is full.
+type OverflowPolicy int
+
+const (
+	// Block makes Dispatch wait until a worker frees a slot in the queue.
+	Block OverflowPolicy = iota
+	// Drop makes Dispatch discard the event and return ErrQueueFull.
+	Drop
+)
+
+// AsyncConfig configures an asynchronous dispatcher. Every event name gets
+// its own bounded queue of QueueSize events consumed by Workers goroutines.
+type AsyncConfig struct {
+	QueueSize int
+	Workers   int
+	Overflow  OverflowPolicy
+}
+
+// ExecutionMode decides how the handlers of one event run.
+type ExecutionMode int
+
+const (
+	// Parallel starts every handler in its own goroutine, in priority order,
+	// and waits for all of them.
+	Parallel ExecutionMode = iota
+	// Sequential runs the handlers one after the other in priority order.
+	Sequential
+	// SequentialStopOnError runs the handlers like Sequential but skips the
+	// remaining ones once a handler fails.
+	SequentialStopOnError
+)
+
+// RegisterOption customizes a handler registration.
+type RegisterOption func(*registration)
+
+type registration struct {
+	priority int
+}
+
+// WithPriority sets the priority of a handler. Handlers with a higher
+// priority run first; handlers of equal priority keep the dispatch order. The
+// default priority is 0.
+func WithPriority(priority int) RegisterOption {
+	return func(r *registration) {
+		r.priority = priority
+	}
+}
+
+func newRegistration(opts []RegisterOption) registration {
+	r := registration{}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// subscription is a handler registered for every event name accepted by
+// match rather than for one exact name.
+type subscription struct {
+	pattern  string
+	match    func(eventName string) bool
+	handler  EventHandlerInterface
+	priority int
+}
 
 type EventDispatcher struct {
-	handlers map[string][]EventHandlerInterface
+	handlers         map[string][]EventHandlerInterface
+	priorities       map[string]map[EventHandlerInterface]int
+	subscriptions    []subscription
+	modes            map[string]ExecutionMode
+	middlewares      []Middleware
+	eventMiddlewares map[string][]Middleware
+	mu               sync.RWMutex
+
+	async   *AsyncConfig
+	queues  map[string]chan EventInterface
+	senders sync.WaitGroup
+	workers sync.WaitGroup
+	closed  bool
 }
 
+// NewEventDispatcher returns a synchronous dispatcher: Dispatch only returns
+// once every handler of the event has finished.
 func NewEventDispatcher() *EventDispatcher {
 	return &EventDispatcher{
-		handlers: make(map[string][]EventHandlerInterface),
+		handlers:         make(map[string][]EventHandlerInterface),
+		priorities:       make(map[string]map[EventHandlerInterface]int),
+		modes:            make(map[string]ExecutionMode),
+		eventMiddlewares: make(map[string][]Middleware),
+	}
+}
+
+// NewAsyncEventDispatcher returns a dispatcher whose Dispatch only enqueues
+// the event. Handlers run on a worker pool per event name, so events of the
+// same name are only processed in order when Workers is 1.
+func NewAsyncEventDispatcher(config AsyncConfig) *EventDispatcher {
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1
 	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+
+	return &EventDispatcher{
+		handlers:         make(map[string][]EventHandlerInterface),
+		priorities:       make(map[string]map[EventHandlerInterface]int),
+		modes:            make(map[string]ExecutionMode),
+		eventMiddlewares: make(map[string][]Middleware),
+		async:            &config,
+		queues:           make(map[string]chan EventInterface),
+	}
+}
+
+// Use adds middlewares wrapping every handler. The first middleware added is
+// the outermost one.
+func (ed *EventDispatcher) Use(middlewares ...Middleware) {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	ed.middlewares = append(ed.middlewares, middlewares...)
 }
 
-func (ed *EventDispatcher) Register(eventName string, handler EventHandlerInterface) error {
+// UseFor sets the middlewares of eventName. They replace the ones added with
+// Use for that event, so a global middleware that should still apply has to
+// be passed again.
+func (ed *EventDispatcher) UseFor(eventName string, middlewares ...Middleware) {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	ed.eventMiddlewares[eventName] = middlewares
+}
+
+// SetMode sets how the handlers of eventName run. Events run in Parallel mode
+// unless set otherwise.
+func (ed *EventDispatcher) SetMode(eventName string, mode ExecutionMode) {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	ed.modes[eventName] = mode
+}
+
+func (ed *EventDispatcher) Register(eventName string, handler EventHandlerInterface, opts ...RegisterOption) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
 	if _, ok := ed.handlers[eventName]; ok {
 		for _, h := range ed.handlers[eventName] {
 			if h == handler {
@@ -29,11 +169,80 @@ func (ed *EventDispatcher) Register(eventName string, handler EventHandlerInterf
 	}
 
 	ed.handlers[eventName] = append(ed.handlers[eventName], handler)
+	if _, ok := ed.priorities[eventName]; !ok {
+		ed.priorities[eventName] = make(map[EventHandlerInterface]int)
+	}
+	ed.priorities[eventName][handler] = newRegistration(opts).priority
+
+	return nil
+}
+
+// RegisterPattern subscribes handler to every event whose name matches
+// pattern: "*" matches all events and a trailing "*" matches by prefix, as in
+// "Transaction*". Any other pattern only matches the exact name.
+func (ed *EventDispatcher) RegisterPattern(pattern string, handler EventHandlerInterface, opts ...RegisterOption) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	for _, s := range ed.subscriptions {
+		if s.pattern == pattern && s.handler == handler {
+			return ErrHandlerAlreadyRegistered
+		}
+	}
+
+	ed.subscriptions = append(ed.subscriptions, subscription{
+		pattern:  pattern,
+		match:    matchPattern(pattern),
+		handler:  handler,
+		priority: newRegistration(opts).priority,
+	})
+
+	return nil
+}
+
+// RegisterMatch subscribes handler to every event whose name satisfies match.
+func (ed *EventDispatcher) RegisterMatch(match func(eventName string) bool, handler EventHandlerInterface, opts ...RegisterOption) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	ed.subscriptions = append(ed.subscriptions, subscription{
+		match:    match,
+		handler:  handler,
+		priority: newRegistration(opts).priority,
+	})
+
+	return nil
+}
+
+// UnregisterPattern removes a subscription made with RegisterPattern.
+func (ed *EventDispatcher) UnregisterPattern(pattern string, handler EventHandlerInterface) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	ed.subscriptions = slices.DeleteFunc(ed.subscriptions, func(s subscription) bool {
+		return s.pattern == pattern && s.handler == handler
+	})
+
+	return nil
+}
+
+// UnregisterMatch removes every subscription made with RegisterMatch for
+// handler.
+func (ed *EventDispatcher) UnregisterMatch(handler EventHandlerInterface) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	ed.subscriptions = slices.DeleteFunc(ed.subscriptions, func(s subscription) bool {
+		return s.pattern == "" && s.handler == handler
+	})
 
 	return nil
 }
 
 func (ed *EventDispatcher) Unregister(eventName string, handler EventHandlerInterface) error {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
 	if _, ok := ed.handlers[eventName]; !ok {
 		return nil
 	}
@@ -41,6 +250,7 @@ func (ed *EventDispatcher) Unregister(eventName string, handler EventHandlerInte
 	for i, h := range ed.handlers[eventName] {
 		if h == handler {
 			ed.handlers[eventName] = append(ed.handlers[eventName][:i], ed.handlers[eventName][i+1:]...)
+			delete(ed.priorities[eventName], handler)
 			return nil
 		}
 	}
@@ -49,10 +259,18 @@ func (ed *EventDispatcher) Unregister(eventName string, handler EventHandlerInte
 }
 
 func (ed *EventDispatcher) Clear() {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
 	ed.handlers = make(map[string][]EventHandlerInterface)
+	ed.priorities = make(map[string]map[EventHandlerInterface]int)
+	ed.subscriptions = nil
 }
 
 func (ed *EventDispatcher) Has(eventName string, handler EventHandlerInterface) bool {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+
 	if _, ok := ed.handlers[eventName]; !ok {
 		return false
 	}
@@ -60,15 +278,192 @@ func (ed *EventDispatcher) Has(eventName string, handler EventHandlerInterface)
 	return slices.Contains(ed.handlers[eventName], handler)
 }
 
+// Dispatch runs the handlers of event by descending priority. Within a
+// priority, the ones registered for its exact name come first, then the
+// pattern and predicate subscriptions, each group in registration order. A
+// handler matched more than once runs only once.
 func (ed *EventDispatcher) Dispatch(event EventInterface) error {
-	if handlers, ok := ed.handlers[event.GetName()]; ok {
-		wg := &sync.WaitGroup{}
+	if ed.async != nil {
+		return ed.enqueue(event)
+	}
+
+	return ed.dispatch(event)
+}
+
+// DispatchOrLog dispatches event and logs, with the default slog logger, the
+// error of an event that could not be dispatched. It suits the events raised
+// once the change they report is committed, which failing the caller would
+// misreport.
+func DispatchOrLog(dispatcher EventDispatcherInterface, event EventInterface) {
+	if err := dispatcher.Dispatch(event); err != nil {
+		slog.Error("dispatching event", slog.String("event", event.GetName()), slog.Any("error", err))
+	}
+}
+
+// Shutdown stops accepting events and waits until the queued ones have been
+// handled or ctx is done. It is a no-op for synchronous dispatchers.
+func (ed *EventDispatcher) Shutdown(ctx context.Context) error {
+	if ed.async == nil {
+		return nil
+	}
+
+	ed.mu.Lock()
+	if ed.closed {
+		ed.mu.Unlock()
+		return nil
+	}
+	ed.closed = true
+	ed.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		// Blocked senders still need the workers to make room, so the
+		// queues can only be closed once all of them have returned.
+		ed.senders.Wait()
+		ed.mu.Lock()
+		for _, queue := range ed.queues {
+			close(queue)
+		}
+		ed.mu.Unlock()
+		ed.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ed *EventDispatcher) dispatch(event EventInterface) error {
+	ed.mu.RLock()
+	handlers := ed.handlersFor(event.GetName())
+	middlewares, ok := ed.eventMiddlewares[event.GetName()]
+	if !ok {
+		middlewares = ed.middlewares
+	}
+	mode := ed.modes[event.GetName()]
+	ed.mu.RUnlock()
+
+	ctx := context.Background()
+	if mode == Sequential || mode == SequentialStopOnError {
+		var errs []error
 		for _, handler := range handlers {
-			wg.Add(1)
-			go handler.Handle(event, wg)
+			if err := Chain(HandlerOf(handler), middlewares...)(ctx, event); err != nil {
+				errs = append(errs, err)
+				if mode == SequentialStopOnError {
+					break
+				}
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	errs := make([]error, len(handlers))
+	wg := &sync.WaitGroup{}
+	for i, handler := range handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = Chain(HandlerOf(handler), middlewares...)(ctx, event)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// handlersFor returns the handlers matching eventName in dispatch order. It
+// must be called with ed.mu held.
+func (ed *EventDispatcher) handlersFor(eventName string) []EventHandlerInterface {
+	type prioritized struct {
+		handler  EventHandlerInterface
+		priority int
+	}
+
+	var matched []prioritized
+	seen := make(map[EventHandlerInterface]bool)
+	for _, h := range ed.handlers[eventName] {
+		matched = append(matched, prioritized{h, ed.priorities[eventName][h]})
+		seen[h] = true
+	}
+	for _, s := range ed.subscriptions {
+		if s.match(eventName) && !seen[s.handler] {
+			matched = append(matched, prioritized{s.handler, s.priority})
+			seen[s.handler] = true
+		}
+	}
+
+	slices.SortStableFunc(matched, func(a, b prioritized) int {
+		return b.priority - a.priority
+	})
+
+	handlers := make([]EventHandlerInterface, len(matched))
+	for i, m := range matched {
+		handlers[i] = m.handler
+	}
+	return handlers
+}
+
+func matchPattern(pattern string) func(eventName string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return func(eventName string) bool {
+			return strings.HasPrefix(eventName, prefix)
+		}
+	}
+	return func(eventName string) bool {
+		return eventName == pattern
+	}
+}
+
+func (ed *EventDispatcher) enqueue(event EventInterface) error {
+	ed.mu.Lock()
+	if ed.closed {
+		ed.mu.Unlock()
+		return ErrDispatcherClosed
+	}
+	if len(ed.handlersFor(event.GetName())) == 0 {
+		ed.mu.Unlock()
+		return nil
+	}
+	queue := ed.queueFor(event.GetName())
+	ed.senders.Add(1)
+	ed.mu.Unlock()
+	defer ed.senders.Done()
+
+	if ed.async.Overflow == Drop {
+		select {
+		case queue <- event:
+			return nil
+		default:
+			return ErrQueueFull
 		}
-		wg.Wait()
 	}
 
+	queue <- event
 	return nil
 }
+
+// queueFor returns the queue of eventName, starting its workers on first use.
+// It must be called with ed.mu held.
+func (ed *EventDispatcher) queueFor(eventName string) chan EventInterface {
+	if queue, ok := ed.queues[eventName]; ok {
+		return queue
+	}
+
+	queue := make(chan EventInterface, ed.async.QueueSize)
+	ed.queues[eventName] = queue
+	for range ed.async.Workers {
+		ed.workers.Add(1)
+		go func() {
+			defer ed.workers.Done()
+			for event := range queue {
+				ed.dispatch(event)
+			}
+		}()
+	}
+
+	return queue
+}
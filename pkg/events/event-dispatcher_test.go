@@ -1,6 +1,10 @@
 package events
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
 	"sync"
 	"testing"
 	"time"
@@ -27,10 +31,6 @@ func (e *TestEvent) GetDateTime() time.Time {
 	return time.Now()
 }
 
-func (e *TestEvent) SetPayload(payload any) {
-	e.Payload = payload
-}
-
 type TestEventHandler struct {
 	ID int
 }
@@ -144,6 +144,173 @@ func (suite *EventDispatcherTestSuite) TestEventDispatcher_Dispatch_Unregister()
 	suite.Equal(0, len(suite.eventDispatcher.handlers[suite.event.GetName()]))
 }
 
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_RegisterPattern() {
+	suite.Nil(suite.eventDispatcher.RegisterPattern("*", &suite.handler))
+	suite.Nil(suite.eventDispatcher.RegisterPattern("test2*", &suite.handler2))
+	suite.Equal(ErrHandlerAlreadyRegistered, suite.eventDispatcher.RegisterPattern("*", &suite.handler))
+
+	suite.Equal([]EventHandlerInterface{&suite.handler}, suite.eventDispatcher.handlersFor(suite.event.GetName()))
+	suite.Equal([]EventHandlerInterface{&suite.handler, &suite.handler2}, suite.eventDispatcher.handlersFor(suite.event2.GetName()))
+
+	suite.Nil(suite.eventDispatcher.UnregisterPattern("*", &suite.handler))
+	suite.Empty(suite.eventDispatcher.handlersFor(suite.event.GetName()))
+}
+
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_RegisterMatch() {
+	isTest2 := func(eventName string) bool { return eventName == "test2" }
+	suite.Nil(suite.eventDispatcher.RegisterMatch(isTest2, &suite.handler))
+
+	suite.Empty(suite.eventDispatcher.handlersFor(suite.event.GetName()))
+	suite.Equal([]EventHandlerInterface{&suite.handler}, suite.eventDispatcher.handlersFor(suite.event2.GetName()))
+
+	suite.Nil(suite.eventDispatcher.UnregisterMatch(&suite.handler))
+	suite.Empty(suite.eventDispatcher.handlersFor(suite.event2.GetName()))
+}
+
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_PatternOrderAndDeduplication() {
+	suite.eventDispatcher.RegisterPattern("*", &suite.handler3)
+	suite.eventDispatcher.RegisterPattern("te*", &suite.handler)
+	suite.eventDispatcher.Register(suite.event.GetName(), &suite.handler)
+	suite.eventDispatcher.Register(suite.event.GetName(), &suite.handler2)
+
+	suite.Equal(
+		[]EventHandlerInterface{&suite.handler, &suite.handler2, &suite.handler3},
+		suite.eventDispatcher.handlersFor(suite.event.GetName()),
+	)
+
+	dispatcher := NewEventDispatcher()
+	eh := &MockHandler{}
+	eh.On("Handle", &suite.event)
+	dispatcher.Register(suite.event.GetName(), eh)
+	dispatcher.RegisterPattern("*", eh)
+	dispatcher.Dispatch(&suite.event)
+	eh.AssertNumberOfCalls(suite.T(), "Handle", 1)
+}
+
+type OrderedHandler struct {
+	name  string
+	calls *[]string
+	err   error
+}
+
+func (h *OrderedHandler) Handle(event EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	h.HandleContext(context.Background(), event)
+}
+
+func (h *OrderedHandler) HandleContext(ctx context.Context, event EventInterface) error {
+	*h.calls = append(*h.calls, h.name)
+	return h.err
+}
+
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_RegisterWithPriority() {
+	suite.eventDispatcher.Register(suite.event.GetName(), &suite.handler)
+	suite.eventDispatcher.Register(suite.event.GetName(), &suite.handler2, WithPriority(10))
+	suite.eventDispatcher.RegisterPattern("*", &suite.handler3, WithPriority(100))
+
+	suite.Equal(
+		[]EventHandlerInterface{&suite.handler3, &suite.handler2, &suite.handler},
+		suite.eventDispatcher.handlersFor(suite.event.GetName()),
+	)
+}
+
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_Dispatch_Sequential() {
+	var calls []string
+	failure := errors.New("failure")
+	suite.eventDispatcher.SetMode(suite.event.GetName(), Sequential)
+	suite.eventDispatcher.Register(suite.event.GetName(), &OrderedHandler{name: "kafka", calls: &calls})
+	suite.eventDispatcher.Register(suite.event.GetName(), &OrderedHandler{name: "projection", calls: &calls, err: failure}, WithPriority(10))
+	suite.eventDispatcher.Register(suite.event.GetName(), &OrderedHandler{name: "audit", calls: &calls}, WithPriority(100))
+
+	err := suite.eventDispatcher.Dispatch(&suite.event)
+	suite.ErrorIs(err, failure)
+	suite.Equal([]string{"audit", "projection", "kafka"}, calls)
+
+	calls = nil
+	suite.eventDispatcher.SetMode(suite.event.GetName(), SequentialStopOnError)
+	err = suite.eventDispatcher.Dispatch(&suite.event)
+	suite.ErrorIs(err, failure)
+	suite.Equal([]string{"audit", "projection"}, calls)
+}
+
+type BlockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func NewBlockingHandler() *BlockingHandler {
+	return &BlockingHandler{
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (h *BlockingHandler) Handle(event EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	h.started <- struct{}{}
+	<-h.release
+}
+
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_Dispatch_Async() {
+	dispatcher := NewAsyncEventDispatcher(AsyncConfig{QueueSize: 10, Workers: 1})
+	eh := NewBlockingHandler()
+	dispatcher.Register(suite.event.GetName(), eh)
+
+	err := dispatcher.Dispatch(&suite.event)
+	suite.Nil(err)
+	<-eh.started
+
+	close(eh.release)
+	err = dispatcher.Shutdown(context.Background())
+	suite.Nil(err)
+
+	err = dispatcher.Dispatch(&suite.event)
+	suite.Equal(ErrDispatcherClosed, err)
+}
+
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_Dispatch_AsyncDropWhenFull() {
+	dispatcher := NewAsyncEventDispatcher(AsyncConfig{QueueSize: 1, Workers: 1, Overflow: Drop})
+	eh := NewBlockingHandler()
+	dispatcher.Register(suite.event.GetName(), eh)
+
+	suite.Nil(dispatcher.Dispatch(&suite.event))
+	<-eh.started
+	suite.Nil(dispatcher.Dispatch(&suite.event))
+	suite.Equal(ErrQueueFull, dispatcher.Dispatch(&suite.event))
+
+	close(eh.release)
+	suite.Nil(dispatcher.Shutdown(context.Background()))
+	suite.Equal(1, len(eh.started))
+}
+
+func (suite *EventDispatcherTestSuite) TestEventDispatcher_Shutdown_Timeout() {
+	dispatcher := NewAsyncEventDispatcher(AsyncConfig{QueueSize: 1, Workers: 1})
+	eh := NewBlockingHandler()
+	dispatcher.Register(suite.event.GetName(), eh)
+
+	suite.Nil(dispatcher.Dispatch(&suite.event))
+	<-eh.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	suite.Equal(context.DeadlineExceeded, dispatcher.Shutdown(ctx))
+	close(eh.release)
+}
+
+func (suite *EventDispatcherTestSuite) TestDispatchOrLog() {
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	dispatcher := NewAsyncEventDispatcher(AsyncConfig{QueueSize: 1, Workers: 1})
+	suite.Nil(dispatcher.Shutdown(context.Background()))
+
+	DispatchOrLog(dispatcher, &suite.event)
+	suite.Contains(logs.String(), `"event":"test"`)
+	suite.Contains(logs.String(), ErrDispatcherClosed.Error())
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(EventDispatcherTestSuite))
 }
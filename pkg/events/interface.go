@@ -1,6 +1,7 @@
 package events
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -9,15 +10,27 @@ type EventInterface interface {
 	GetName() string
 	GetDateTime() time.Time
 	GetPayload() any
-	SetPayload(payload any)
+}
+
+// EnvelopedEvent is implemented by events embedding an Envelope.
+type EnvelopedEvent interface {
+	EventInterface
+	GetEnvelope() Envelope
 }
 
 type EventHandlerInterface interface {
 	Handle(event EventInterface, wg *sync.WaitGroup)
 }
 
+// ContextEventHandler is implemented by handlers that accept a context and
+// report failures, which lets middlewares such as Retry and Timeout act on
+// them. The dispatcher prefers HandleContext over Handle when both exist.
+type ContextEventHandler interface {
+	HandleContext(ctx context.Context, event EventInterface) error
+}
+
 type EventDispatcherInterface interface {
-	Register(eventName string, handler EventHandlerInterface) error
+	Register(eventName string, handler EventHandlerInterface, opts ...RegisterOption) error
 	Dispatch(event EventInterface) error
 	Unregister(eventName string, handler EventHandlerInterface) error
 	Has(eventName string, handler EventHandlerInterface) bool
@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var ErrHandlerPanic = errors.New("event handler panicked")
+
+// Handler is the shape every registered handler takes inside the middleware
+// chain.
+type Handler func(ctx context.Context, event EventInterface) error
+
+// Middleware wraps a Handler with cross-cutting behaviour such as logging or
+// retries.
+type Middleware func(next Handler) Handler
+
+// Chain applies middlewares to handler so that the first one is the outermost.
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// HandlerOf adapts a registered handler to a Handler.
+func HandlerOf(handler EventHandlerInterface) Handler {
+	if h, ok := handler.(ContextEventHandler); ok {
+		return h.HandleContext
+	}
+
+	return func(ctx context.Context, event EventInterface) error {
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		handler.Handle(event, wg)
+		wg.Wait()
+		return nil
+	}
+}
+
+// Recovery turns a panicking handler into one returning ErrHandlerPanic.
+func Recovery() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, event EventInterface) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+				}
+			}()
+			return next(ctx, event)
+		}
+	}
+}
+
+// Retry calls the handler up to attempts times while it fails, doubling the
+// wait between attempts starting from backoff.
+func Retry(attempts int, backoff time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, event EventInterface) error {
+			var err error
+			wait := backoff
+			for attempt := 1; ; attempt++ {
+				if err = next(ctx, event); err == nil || attempt >= attempts {
+					return err
+				}
+
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return errors.Join(err, ctx.Err())
+				}
+				wait *= 2
+			}
+		}
+	}
+}
+
+// Timeout cancels the handler context after d and returns without waiting for
+// the handler to notice. A panic in the handler is not recovered here, so
+// Recovery has to be placed after Timeout in the chain. It only suits handlers
+// that stop on a cancelled context; placed after Retry, a handler ignoring it
+// would be called again while the timed-out call still runs.
+func Timeout(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, event EventInterface) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			result := make(chan error, 1)
+			go func() {
+				result <- next(ctx, event)
+			}()
+
+			select {
+			case err := <-result:
+				return err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// Logging writes one structured record per handler call with its duration
+// and outcome.
+func Logging(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, event EventInterface) error {
+			start := time.Now()
+			err := next(ctx, event)
+
+			attrs := []any{
+				slog.String("event", event.GetName()),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if e, ok := event.(EnvelopedEvent); ok {
+				envelope := e.GetEnvelope()
+				attrs = append(attrs, slog.String("event_id", envelope.ID), slog.String("correlation_id", envelope.CorrelationID))
+			}
+			if err != nil {
+				logger.ErrorContext(ctx, "event handler failed", append(attrs, slog.Any("error", err))...)
+				return err
+			}
+			logger.InfoContext(ctx, "event handled", attrs...)
+			return nil
+		}
+	}
+}
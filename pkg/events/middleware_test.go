@@ -0,0 +1,108 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RecordingHandler struct {
+	mu     sync.Mutex
+	calls  int
+	errs   []error
+	panics bool
+}
+
+func (h *RecordingHandler) Handle(event EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+	h.HandleContext(context.Background(), event)
+}
+
+func (h *RecordingHandler) HandleContext(ctx context.Context, event EventInterface) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	if h.panics {
+		panic("boom")
+	}
+	if len(h.errs) > 0 {
+		err := h.errs[0]
+		h.errs = h.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func tagging(tag string, calls *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, event EventInterface) error {
+			*calls = append(*calls, tag)
+			return next(ctx, event)
+		}
+	}
+}
+
+func TestEventDispatcher_Use(t *testing.T) {
+	event := &TestEvent{Name: "test"}
+	dispatcher := NewEventDispatcher()
+	dispatcher.Register(event.GetName(), &RecordingHandler{})
+
+	var calls []string
+	dispatcher.Use(tagging("outer", &calls), tagging("inner", &calls))
+	dispatcher.Dispatch(event)
+
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+}
+
+func TestEventDispatcher_UseFor(t *testing.T) {
+	event := &TestEvent{Name: "test"}
+	dispatcher := NewEventDispatcher()
+	dispatcher.Register(event.GetName(), &RecordingHandler{})
+	dispatcher.Register("other", &RecordingHandler{})
+
+	var calls []string
+	dispatcher.Use(tagging("global", &calls))
+	dispatcher.UseFor(event.GetName(), tagging("test", &calls))
+	dispatcher.Dispatch(event)
+	dispatcher.Dispatch(&TestEvent{Name: "other"})
+
+	assert.Equal(t, []string{"test", "global"}, calls)
+}
+
+func TestRecovery(t *testing.T) {
+	event := &TestEvent{Name: "test"}
+	dispatcher := NewEventDispatcher()
+	dispatcher.Register(event.GetName(), &RecordingHandler{panics: true})
+	dispatcher.Use(Recovery())
+
+	err := dispatcher.Dispatch(event)
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+}
+
+func TestRetry(t *testing.T) {
+	failure := errors.New("failure")
+	handler := &RecordingHandler{errs: []error{failure, failure}}
+
+	err := Retry(3, time.Millisecond)(handler.HandleContext)(context.Background(), &TestEvent{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, handler.calls)
+
+	handler = &RecordingHandler{errs: []error{failure, failure}}
+	err = Retry(2, time.Millisecond)(handler.HandleContext)(context.Background(), &TestEvent{})
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 2, handler.calls)
+}
+
+func TestTimeout(t *testing.T) {
+	slow := func(ctx context.Context, event EventInterface) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}
+
+	err := Timeout(10*time.Millisecond)(slow)(context.Background(), &TestEvent{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
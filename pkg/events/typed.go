@@ -0,0 +1,71 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrUnexpectedPayload = errors.New("unexpected event payload type")
+
+// Event is an event whose payload type is known at compile time. It satisfies
+// EventInterface, so it goes through the regular dispatcher.
+type Event[T any] struct {
+	Envelope
+	Payload T `json:"payload"`
+}
+
+func NewEvent[T any](ctx context.Context, name string, version int, aggregateType string, aggregateID string, payload T) *Event[T] {
+	return &Event[T]{
+		Envelope: NewEnvelope(ctx, name, version, aggregateType, aggregateID),
+		Payload:  payload,
+	}
+}
+
+func (e *Event[T]) GetPayload() any {
+	return e.Payload
+}
+
+// HandlerFunc handles events carrying a payload of type T.
+type HandlerFunc[T any] func(ctx context.Context, event *Event[T]) error
+
+// Subscribe registers fn for eventName and returns the handler it registered,
+// which is what Unregister expects. An event of that name whose payload is not
+// a T makes the handler fail with ErrUnexpectedPayload.
+func Subscribe[T any](dispatcher EventDispatcherInterface, eventName string, fn HandlerFunc[T], opts ...RegisterOption) (EventHandlerInterface, error) {
+	handler := &typedHandler[T]{fn: fn}
+	if err := dispatcher.Register(eventName, handler, opts...); err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
+type typedHandler[T any] struct {
+	fn HandlerFunc[T]
+}
+
+func (h *typedHandler[T]) Handle(event EventInterface, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	h.HandleContext(context.Background(), event)
+}
+
+func (h *typedHandler[T]) HandleContext(ctx context.Context, event EventInterface) error {
+	if e, ok := event.(*Event[T]); ok {
+		return h.fn(ctx, e)
+	}
+
+	payload, ok := event.GetPayload().(T)
+	if !ok {
+		return fmt.Errorf("%w: %s carries %T", ErrUnexpectedPayload, event.GetName(), event.GetPayload())
+	}
+
+	typed := &Event[T]{Payload: payload}
+	if e, ok := event.(EnvelopedEvent); ok {
+		typed.Envelope = e.GetEnvelope()
+	} else {
+		typed.Envelope = Envelope{Name: event.GetName(), OccurredAt: event.GetDateTime()}
+	}
+	return h.fn(ctx, typed)
+}
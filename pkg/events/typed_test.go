@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TransferPayload struct {
+	ID     string
+	Amount float64
+}
+
+func TestSubscribe(t *testing.T) {
+	dispatcher := NewEventDispatcher()
+
+	var received []TransferPayload
+	handler, err := Subscribe(dispatcher, "Transfer", func(ctx context.Context, event *Event[TransferPayload]) error {
+		received = append(received, event.Payload)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, dispatcher.Has("Transfer", handler))
+
+	event := NewEvent(context.Background(), "Transfer", 1, "Transaction", "1", TransferPayload{ID: "1", Amount: 10})
+	assert.Nil(t, dispatcher.Dispatch(event))
+	assert.Nil(t, dispatcher.Dispatch(&TestEvent{Name: "Transfer", Payload: TransferPayload{ID: "2", Amount: 20}}))
+
+	assert.Equal(t, []TransferPayload{{ID: "1", Amount: 10}, {ID: "2", Amount: 20}}, received)
+}
+
+func TestSubscribe_UnexpectedPayload(t *testing.T) {
+	dispatcher := NewEventDispatcher()
+	Subscribe(dispatcher, "Transfer", func(ctx context.Context, event *Event[TransferPayload]) error {
+		return nil
+	})
+
+	err := dispatcher.Dispatch(&TestEvent{Name: "Transfer", Payload: "not a transfer"})
+	assert.ErrorIs(t, err, ErrUnexpectedPayload)
+}
@@ -2,20 +2,42 @@ package kafka
 
 import (
 	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
 
 	ckafka "github.com/confluentinc/confluent-kafka-go/kafka"
 )
 
+var ErrProducerClosed = errors.New("kafka producer is closed")
+
 type Producer struct {
 	ConfigMap *ckafka.ConfigMap
+
+	once     sync.Once
+	producer *ckafka.Producer
+	err      error
+
+	// mu keeps Close from releasing the producer under a running Publish.
+	mu     sync.RWMutex
+	closed bool
 }
 
 func NewKafkaProducer(configMap *ckafka.ConfigMap) *Producer {
 	return &Producer{ConfigMap: configMap}
 }
 
+// Publish queues msg for delivery. Delivery itself is reported
+// asynchronously; failed deliveries are logged.
 func (p *Producer) Publish(msg interface{}, key []byte, topic string) error {
-	producer, err := ckafka.NewProducer(p.ConfigMap)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrProducerClosed
+	}
+
+	producer, err := p.client()
 	if err != nil {
 		return err
 	}
@@ -30,9 +52,56 @@ func (p *Producer) Publish(msg interface{}, key []byte, topic string) error {
 		Value:          msgJson,
 		Key:            key,
 	}
-	err = producer.Produce(message, nil)
-	if err != nil {
-		panic(err)
+	return producer.Produce(message, nil)
+}
+
+// Close waits up to timeout for the published messages to be delivered and
+// releases the producer. It returns the number of messages still pending.
+// Publish fails with ErrProducerClosed afterwards.
+func (p *Producer) Close(timeout time.Duration) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return 0
+	}
+	p.closed = true
+
+	// A producer not created yet never will be.
+	p.once.Do(func() {
+		p.err = ErrProducerClosed
+	})
+	if p.producer == nil {
+		return 0
+	}
+
+	pending := p.producer.Flush(int(timeout.Milliseconds()))
+	p.producer.Close()
+	return pending
+}
+
+// client creates the underlying producer on first use and shares it between
+// calls to Publish.
+func (p *Producer) client() (*ckafka.Producer, error) {
+	p.once.Do(func() {
+		p.producer, p.err = ckafka.NewProducer(p.ConfigMap)
+		if p.err == nil {
+			go drainEvents(p.producer.Events())
+		}
+	})
+	return p.producer, p.err
+}
+
+// drainEvents reads the delivery reports of the producer until it is closed,
+// so that they never fill its events channel and stall it.
+func drainEvents(events chan ckafka.Event) {
+	for e := range events {
+		switch ev := e.(type) {
+		case *ckafka.Message:
+			if ev.TopicPartition.Error != nil {
+				slog.Error("kafka delivery failed", "topic", *ev.TopicPartition.Topic, "key", string(ev.Key), "error", ev.TopicPartition.Error)
+			}
+		case ckafka.Error:
+			slog.Error("kafka producer error", "error", ev)
+		}
 	}
-	return nil
 }
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"testing"
+	"time"
 
 	ckafka "github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/stretchr/testify/assert"
@@ -27,3 +28,22 @@ func TestProducerPublish(t *testing.T) {
 	err := producer.Publish(expectedOutput, []byte("1"), "test")
 	assert.Nil(t, err)
 }
+
+func TestProducerPublishAfterClose(t *testing.T) {
+	configMap := ckafka.ConfigMap{
+		"test.mock.num.brokers": 3,
+	}
+	producer := NewKafkaProducer(&configMap)
+	assert.Nil(t, producer.Publish("message", []byte("1"), "test"))
+	assert.Equal(t, 0, producer.Close(10*time.Second))
+
+	err := producer.Publish("message", []byte("1"), "test")
+	assert.ErrorIs(t, err, ErrProducerClosed)
+	assert.Equal(t, 0, producer.Close(time.Second))
+}
+
+func TestProducerCloseUnused(t *testing.T) {
+	producer := NewKafkaProducer(&ckafka.ConfigMap{})
+	assert.Equal(t, 0, producer.Close(time.Second))
+	assert.ErrorIs(t, producer.Publish("message", nil, "test"), ErrProducerClosed)
+}
@@ -51,6 +51,10 @@ func (u *Uow) GetRepository(ctx context.Context, name string) (interface{}, erro
 	return repo, nil
 }
 
+// Do runs fn in a database transaction of its own, committed when fn returns
+// nil and rolled back otherwise. fn is handed a Uow bound to that transaction,
+// which its repositories must be taken from: u itself never holds it, so that
+// one Uow can be shared by concurrent callers.
 func (u *Uow) Do(ctx context.Context, fn func(Uow *Uow) error) error {
 	if u.Tx != nil {
 		return fmt.Errorf("transaction already started")
@@ -59,16 +63,20 @@ func (u *Uow) Do(ctx context.Context, fn func(Uow *Uow) error) error {
 	if err != nil {
 		return err
 	}
-	u.Tx = tx
-	err = fn(u)
+	txUow := &Uow{
+		Db:           u.Db,
+		Tx:           tx,
+		Repositories: u.Repositories,
+	}
+	err = fn(txUow)
 	if err != nil {
-		errRb := u.Rollback()
+		errRb := txUow.Rollback()
 		if errRb != nil {
-			return fmt.Errorf("original error: %s, rollback error: %s", err.Error(), errRb.Error())
+			return fmt.Errorf("original error: %w, rollback error: %s", err, errRb.Error())
 		}
 		return err
 	}
-	return u.CommitOrRollback()
+	return txUow.CommitOrRollback()
 }
 
 func (u *Uow) Rollback() error {
@@ -88,7 +96,7 @@ func (u *Uow) CommitOrRollback() error {
 	if err != nil {
 		errRb := u.Rollback()
 		if errRb != nil {
-			return fmt.Errorf("original error: %s, rollback error: %s", err.Error(), errRb.Error())
+			return fmt.Errorf("original error: %w, rollback error: %s", err, errRb.Error())
 		}
 		return err
 	}
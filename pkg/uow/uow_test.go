@@ -0,0 +1,105 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+type countRepository struct {
+	tx *sql.Tx
+}
+
+func (r *countRepository) Count() (int, error) {
+	var count int
+	err := r.tx.QueryRow("SELECT COUNT(*) FROM items").Scan(&count)
+	return count, err
+}
+
+func (r *countRepository) Add(name string) error {
+	_, err := r.tx.Exec("INSERT INTO items (name) VALUES (?)", name)
+	return err
+}
+
+func newTestUow(t *testing.T) *Uow {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "uow.db"))
+	assert.Nil(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec("CREATE TABLE items (name varchar(255))")
+	assert.Nil(t, err)
+
+	u := NewUow(context.Background(), db)
+	u.Register("Items", func(tx *sql.Tx) interface{} {
+		return &countRepository{tx: tx}
+	})
+	return u
+}
+
+func items(t *testing.T, u *Uow) *countRepository {
+	repo, err := u.GetRepository(context.Background(), "Items")
+	assert.Nil(t, err)
+	return repo.(*countRepository)
+}
+
+func TestUow_Do_Commits(t *testing.T) {
+	u := newTestUow(t)
+
+	err := u.Do(context.Background(), func(tx *Uow) error {
+		assert.NotSame(t, u, tx)
+		assert.NotNil(t, tx.Tx)
+		assert.Nil(t, u.Tx)
+		return items(t, tx).Add("a")
+	})
+
+	assert.Nil(t, err)
+	var count int
+	assert.Nil(t, u.Db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestUow_Do_RollsBack(t *testing.T) {
+	u := newTestUow(t)
+	failure := errors.New("failure")
+
+	err := u.Do(context.Background(), func(tx *Uow) error {
+		assert.Nil(t, items(t, tx).Add("a"))
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	var count int
+	assert.Nil(t, u.Db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestUow_Do_Concurrent(t *testing.T) {
+	u := newTestUow(t)
+
+	// Both calls are inside fn at the same time before either returns.
+	var inside sync.WaitGroup
+	inside.Add(2)
+	var done sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			errs[i] = u.Do(context.Background(), func(tx *Uow) error {
+				inside.Done()
+				inside.Wait()
+				_, err := items(t, tx).Count()
+				return err
+			})
+		}()
+	}
+	done.Wait()
+
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+}
@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"errors"
+	"math"
+	"net/mail"
+	"slices"
+	"strings"
+)
+
+// ErrInvalid is matched, through errors.Is, by every non-empty Errors.
+var ErrInvalid = errors.New("validation failed")
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors collects every field error of a value, so that they can all be
+// reported at once.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Field+": "+fieldError.Message)
+	}
+	return ErrInvalid.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e Errors) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+func (e *Errors) Add(field string, code string, message string) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns nil when no error was added, so that a Validate method can
+// end with "return errs.Err()".
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Required adds a "required" error when value is blank.
+func (e *Errors) Required(field string, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		e.Add(field, "required", "is required")
+		return false
+	}
+	return true
+}
+
+// Email adds an "invalid_email" error when value is not a bare email address.
+func (e *Errors) Email(field string, value string) bool {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		e.Add(field, "invalid_email", "must be an email address")
+		return false
+	}
+	return true
+}
+
+// Positive adds a "must_be_positive" error when value is not a finite number
+// greater than zero.
+func (e *Errors) Positive(field string, value float64) bool {
+	if math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 {
+		e.Add(field, "must_be_positive", "must be a number greater than zero")
+		return false
+	}
+	return true
+}
+
+// Cents adds a "must_be_whole_cents" error when value has fractions of a
+// cent.
+func (e *Errors) Cents(field string, value float64) bool {
+	if math.Abs(value*100-math.Round(value*100)) > 1e-6 {
+		e.Add(field, "must_be_whole_cents", "must not have fractions of a cent")
+		return false
+	}
+	return true
+}
+
+// NonNegative adds a "must_not_be_negative" error when value is not a finite
+// number of zero or more.
+func (e *Errors) NonNegative(field string, value float64) bool {
+	if math.IsNaN(value) || math.IsInf(value, 0) || value < 0 {
+		e.Add(field, "must_not_be_negative", "must be a number of zero or more")
+		return false
+	}
+	return true
+}
+
+// OneOf adds an "invalid_choice" error when value is not one of choices.
+func (e *Errors) OneOf(field string, value string, choices []string) bool {
+	if !slices.Contains(choices, value) {
+		e.Add(field, "invalid_choice", "must be one of "+strings.Join(choices, ", "))
+		return false
+	}
+	return true
+}
+
+// Currency adds an "invalid_currency" error when value is not an ISO 4217
+// code: three upper case letters.
+func (e *Errors) Currency(field string, value string) bool {
+	valid := len(value) == 3
+	for _, r := range value {
+		valid = valid && r >= 'A' && r <= 'Z'
+	}
+	if !valid {
+		e.Add(field, "invalid_currency", "must be an ISO 4217 currency code")
+		return false
+	}
+	return true
+}
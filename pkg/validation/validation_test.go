@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	var errs Errors
+	assert.Nil(t, errs.Err())
+
+	assert.False(t, errs.Required("name", "  "))
+	assert.True(t, errs.Required("name", "John"))
+	assert.False(t, errs.Email("email", "John <j@j.com>"))
+	assert.False(t, errs.Email("email", "not an email"))
+	assert.True(t, errs.Email("email", "j@j.com"))
+	assert.False(t, errs.Positive("amount", 0))
+	assert.False(t, errs.Positive("amount", -1))
+	assert.False(t, errs.Positive("amount", math.NaN()))
+	assert.False(t, errs.Positive("amount", math.Inf(1)))
+	assert.True(t, errs.Positive("amount", 0.01))
+
+	err := errs.Err()
+	assert.Len(t, errs, 7)
+	assert.Equal(t, FieldError{Field: "name", Code: "required", Message: "is required"}, errs[0])
+	assert.ErrorIs(t, err, ErrInvalid)
+	assert.ErrorIs(t, fmt.Errorf("creating client: %w", err), ErrInvalid)
+	assert.Contains(t, err.Error(), "name: is required; email: must be an email address")
+
+	var target Errors
+	assert.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &target))
+	assert.Len(t, target, 7)
+}
+
+func TestErrors_Currency(t *testing.T) {
+	var errs Errors
+	assert.True(t, errs.Currency("currency", "BRL"))
+	assert.False(t, errs.Currency("currency", "brl"))
+	assert.False(t, errs.Currency("currency", "BRLX"))
+	assert.False(t, errs.Currency("currency", "R$"))
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "invalid_currency", errs[0].Code)
+}
+
+func TestErrors_OneOf(t *testing.T) {
+	var errs Errors
+	assert.True(t, errs.OneOf("mode", "all", []string{"outgoing", "all"}))
+	assert.False(t, errs.OneOf("mode", "incoming", []string{"outgoing", "all"}))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, FieldError{Field: "mode", Code: "invalid_choice", Message: "must be one of outgoing, all"}, errs[0])
+}
+
+func TestErrors_NonNegative(t *testing.T) {
+	var errs Errors
+	assert.True(t, errs.NonNegative("overdraft_limit", 0))
+	assert.True(t, errs.NonNegative("overdraft_limit", 100))
+	assert.False(t, errs.NonNegative("overdraft_limit", -0.01))
+	assert.False(t, errs.NonNegative("overdraft_limit", math.Inf(1)))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "must_not_be_negative", errs[0].Code)
+}
+
+func TestErrors_Cents(t *testing.T) {
+	var errs Errors
+	assert.True(t, errs.Cents("amount", 10))
+	assert.True(t, errs.Cents("amount", 0.29))
+	assert.False(t, errs.Cents("amount", 0.004))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "must_be_whole_cents", errs[0].Code)
+}